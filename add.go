@@ -0,0 +1,346 @@
+package iso8601
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// FractionPolicy defines how fractional years and months are added to a time.Time,
+// as they do not have a fixed length.
+type FractionPolicy int
+
+const (
+	// FractionsError returns an error for fractional years and months, like AddToTime.
+	FractionsError FractionPolicy = iota
+	// FractionsSpread spreads fractional years into months, e.g. "P1.5Y" results in 18 months.
+	// The remaining fraction of a month is added as the respective part of the actual length of the
+	// target month, i.e. the month following the date reached by adding all whole years and months,
+	// which is measured with the MonthEnd policy, e.g. February for January 31 with MonthEndClamp.
+	FractionsSpread
+	// FractionsNominal adds fractional years and months as the respective part of the nominal lengths
+	// AddOptions.NominalYear and AddOptions.NominalMonth.
+	FractionsNominal
+)
+
+// MonthEndPolicy defines how adding years and months handles a day that does not exist in the resulting month,
+// e.g. adding "P1M" to January 31.
+type MonthEndPolicy int
+
+const (
+	// MonthEndOverflow normalizes the overflow into the following month like time.Time.AddDate,
+	// e.g. January 31 + "P1M" results in March 2 or 3.
+	MonthEndOverflow MonthEndPolicy = iota
+	// MonthEndClamp clamps the day to the last day of the resulting month,
+	// e.g. January 31 + "P1M" results in February 28 or 29.
+	MonthEndClamp
+	// MonthEndError returns ErrMonthEnd if the day does not exist in the resulting month.
+	MonthEndError
+)
+
+// AddMode defines which components of a Duration are added on the wall clock and which as elapsed time.
+// Both only differ for locations with daylight saving time or other offset changes.
+type AddMode int
+
+const (
+	// AddModeMixed adds years, months, weeks and days on the wall clock like time.Time.AddDate, and all other
+	// components as elapsed time like time.Time.Add. Thus "P1D" always keeps the local time of day,
+	// while "PT24H" does not across a daylight saving time change.
+	AddModeMixed AddMode = iota
+	// AddModeNominal adds every component on the wall clock, e.g. "PT1H" added to 01:30 always results in 02:30
+	// local time, even if only 0 or 2 hours have elapsed due to a daylight saving time change.
+	AddModeNominal
+	// AddModeExact adds weeks, days and all smaller components as elapsed time, so that a day always lasts 24 hours.
+	// Years and months have no fixed length, thus they are still added on the wall clock like AddModeMixed first.
+	AddModeExact
+)
+
+// LocalTimePolicy defines how a local wall clock time is resolved that does not exist or is ambiguous
+// due to an offset change in its location, e.g. 02:30 at the start or end of daylight saving time in Europe/Berlin.
+type LocalTimePolicy int
+
+const (
+	// LocalTimeDefault resolves nonexistent and ambiguous times like time.Date, i.e. exactly like time.Time.AddDate.
+	// Which of two ambiguous times is chosen depends on the location and is not guaranteed.
+	LocalTimeDefault LocalTimePolicy = iota
+	// LocalTimeCompatible resolves ambiguous times to the earlier one and shifts nonexistent times
+	// forward by the length of the gap, e.g. 02:30 to 03:30.
+	LocalTimeCompatible
+	// LocalTimeEarlier resolves ambiguous times to the earlier one and shifts nonexistent times
+	// backward by the length of the gap, e.g. 02:30 to 01:30.
+	LocalTimeEarlier
+	// LocalTimeLater resolves ambiguous times to the later one and shifts nonexistent times
+	// forward by the length of the gap, e.g. 02:30 to 03:30.
+	LocalTimeLater
+	// LocalTimeReject returns ErrLocalTime for nonexistent and ambiguous times.
+	LocalTimeReject
+)
+
+// AddOptions configures Duration.AddToTimeWithOptions.
+// The zero value behaves exactly like AddToTime.
+type AddOptions struct {
+	// Fractions defines how fractional years and months are handled.
+	Fractions FractionPolicy
+	// NominalYear is the length of a year used by FractionsNominal. Defaults to TimeYear if not positive.
+	NominalYear time.Duration
+	// NominalMonth is the length of a month used by FractionsNominal. Defaults to TimeMonth if not positive.
+	NominalMonth time.Duration
+
+	// MonthEnd defines how days that do not exist in the resulting month are handled.
+	MonthEnd MonthEndPolicy
+
+	// Mode defines which components are added on the wall clock and which as elapsed time.
+	Mode AddMode
+	// LocalTime defines how nonexistent and ambiguous local times are resolved.
+	LocalTime LocalTimePolicy
+}
+
+// applyToTime adds the absolute duration to stdTime if isForward is true, otherwise it subtracts it.
+func (d Duration) applyToTime(stdTime time.Time, isForward bool, opts AddOptions) (time.Time, error) {
+	if d.IsZero() {
+		return stdTime, nil
+	}
+
+	switch opts.Mode {
+	case AddModeNominal:
+		// the whole duration is added to the wall clock, which has no offset changes in UTC
+		opts.Mode = AddModeMixed
+		out, err := d.applyToTime(wallClock(stdTime), isForward, opts)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		return opts.inLocation(out, stdTime)
+	case AddModeExact:
+		// years and months are added on the local calendar, which may differ from the UTC calendar
+		opts.Mode = AddModeMixed
+		calendar := Duration{isPositive: d.isPositive, years: d.years, months: d.months}
+		out, err := calendar.applyToTime(stdTime, isForward, opts)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		// all other components are added in UTC, which has no offset changes
+		elapsed := d
+		elapsed.years = decimal{}
+		elapsed.months = decimal{}
+		out, err = elapsed.applyToTime(out.UTC(), isForward, opts)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		return out.In(stdTime.Location()), nil
+	}
+
+	multiplier := 1
+	if !isForward {
+		multiplier = -1
+	}
+
+	years := d.years
+	months := d.months
+	if opts.Fractions == FractionsSpread {
+		// fractional years are spread exactly into months
+		yearMonths, ok := years.mul(12)
+		if ok {
+			months, ok = months.add(yearMonths)
+		}
+		if !ok {
+			return time.Time{}, errors.New("could not convert year to month")
+		}
+		years = decimal{}
+	}
+
+	yearAdd, err := decimal{whole: years.whole}.int()
+	if err != nil || (years.hasFraction() && opts.Fractions == FractionsError) {
+		return time.Time{}, errors.New("could not convert year to int")
+	}
+	monthAdd, err := decimal{whole: months.whole}.int()
+	if err != nil || (months.hasFraction() && opts.Fractions == FractionsError) {
+		return time.Time{}, errors.New("could not convert month to int")
+	}
+
+	// fractions are spread exactly into the next smaller unit
+	weekAdd, err := decimal{whole: d.weeks.whole}.int()
+	if err != nil {
+		return time.Time{}, errors.New("could not convert week to int")
+	}
+
+	days, ok := d.days.add(d.weeks.spreadFraction(7))
+	dayAdd, err := decimal{whole: days.whole}.int()
+	if !ok || err != nil || weekAdd > (math.MaxInt-dayAdd)/7 {
+		return time.Time{}, errors.New("could not convert day + remaining week to int")
+	}
+
+	hours, ok := d.hours.add(days.spreadFraction(24))
+	if !ok {
+		return time.Time{}, errors.New("could not convert hour + remaining day to int")
+	}
+	minutes, ok := d.minutes.add(hours.spreadFraction(60))
+	if !ok {
+		return time.Time{}, errors.New("could not convert minute + remaining hour to int")
+	}
+	seconds, ok := d.seconds.add(minutes.spreadFraction(60))
+	if !ok {
+		return time.Time{}, errors.New("could not convert second + remaining minute to int")
+	}
+
+	timeSum := newDurationSum(false)
+	if !timeSum.addDecimal(decimal{whole: hours.whole}, time.Hour) ||
+		!timeSum.addDecimal(decimal{whole: minutes.whole}, time.Minute) ||
+		!timeSum.addDecimal(seconds, time.Second) {
+		return time.Time{}, errors.New("time part exceeds time.Duration capacity")
+	}
+	timeAdd := timeSum.duration()
+
+	// the intermediate date is only used to measure the target month, thus its local time never needs to be rejected
+	monthOpts := opts
+	monthOpts.LocalTime = LocalTimeDefault
+	monthDate, err := monthOpts.addDate(stdTime, multiplier*yearAdd, multiplier*monthAdd, 0)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	fractionAdd, err := monthOpts.calendarFractionDuration(monthDate, multiplier, years, months)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if fractionAdd > math.MaxInt64-timeAdd {
+		return time.Time{}, errors.New("time part exceeds time.Duration capacity")
+	}
+
+	out, err := opts.addDate(stdTime, multiplier*yearAdd, multiplier*monthAdd, multiplier*(weekAdd*7+dayAdd))
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return out.Add(time.Duration(multiplier) * (fractionAdd + timeAdd)), nil
+}
+
+// addDate works like time.Time.AddDate, but handles days that do not exist in the resulting month
+// according to the MonthEnd policy of opts.
+func (opts AddOptions) addDate(stdTime time.Time, years, months, days int) (time.Time, error) {
+	year, month, day := stdTime.Date()
+	targetMonth := month + time.Month(months)
+
+	if opts.MonthEnd != MonthEndOverflow {
+		// day 0 of the following month is normalized to the last day of the resulting month
+		lastDay := time.Date(year+years, targetMonth+1, 0, 0, 0, 0, 0, time.UTC).Day()
+		if day > lastDay {
+			if opts.MonthEnd == MonthEndError {
+				return time.Time{}, ErrMonthEnd
+			}
+			day = lastDay
+		}
+	}
+
+	hour, minute, second := stdTime.Clock()
+	wall := time.Date(year+years, targetMonth, day+days, hour, minute, second, stdTime.Nanosecond(), time.UTC)
+
+	return opts.inLocation(wall, stdTime)
+}
+
+// wallClock returns the wall clock of stdTime as time.Time in UTC.
+func wallClock(stdTime time.Time) time.Time {
+	year, month, day := stdTime.Date()
+	hour, minute, second := stdTime.Clock()
+
+	return time.Date(year, month, day, hour, minute, second, stdTime.Nanosecond(), time.UTC)
+}
+
+// inLocation returns the time.Time in the location of origin that has the wall clock of wall, which must be in UTC.
+// Nonexistent and ambiguous wall clocks are resolved according to the LocalTime policy of opts.
+// If wall is the wall clock of origin, origin is returned, so that ambiguous times keep their offset.
+func (opts AddOptions) inLocation(wall time.Time, origin time.Time) (time.Time, error) {
+	loc := origin.Location()
+	if loc == time.UTC {
+		return wall, nil
+	}
+	if wall.Equal(wallClock(origin)) {
+		return origin, nil
+	}
+	if opts.LocalTime == LocalTimeDefault {
+		year, month, day := wall.Date()
+		hour, minute, second := wall.Clock()
+
+		return time.Date(year, month, day, hour, minute, second, wall.Nanosecond(), loc), nil
+	}
+
+	// offset changes are at least a day apart, thus these are the offsets in effect before and after wall
+	_, offsetBefore := wall.Add(-TimeDay).In(loc).Zone()
+	_, offsetAfter := wall.Add(TimeDay).In(loc).Zone()
+
+	first := wall.Add(-time.Duration(offsetBefore) * time.Second).In(loc)
+	second := wall.Add(-time.Duration(offsetAfter) * time.Second).In(loc)
+	isFirstValid := wallClock(first).Equal(wall)
+	isSecondValid := wallClock(second).Equal(wall)
+
+	switch {
+	case isFirstValid && isSecondValid && !first.Equal(second):
+		// ambiguous, e.g. at the end of daylight saving time
+		if opts.LocalTime == LocalTimeReject {
+			return time.Time{}, ErrLocalTime
+		}
+		if (opts.LocalTime == LocalTimeLater) == first.Before(second) {
+			return second, nil
+		}
+
+		return first, nil
+	case isFirstValid:
+		return first, nil
+	case isSecondValid:
+		return second, nil
+	}
+
+	// nonexistent, e.g. at the start of daylight saving time
+	switch opts.LocalTime {
+	case LocalTimeReject:
+		return time.Time{}, ErrLocalTime
+	case LocalTimeEarlier:
+		// interpreted with the offset after the gap, which shifts it backward
+		return second, nil
+	}
+
+	// interpreted with the offset before the gap, which shifts it forward
+	return first, nil
+}
+
+// calendarFractionDuration returns the absolute time.Duration of the fractions of years and months.
+// monthDate is the date reached by adding all whole years and months.
+func (opts AddOptions) calendarFractionDuration(
+	monthDate time.Time,
+	multiplier int,
+	years, months decimal,
+) (time.Duration, error) {
+	switch opts.Fractions {
+	case FractionsSpread:
+		if !months.hasFraction() {
+			return 0, nil
+		}
+
+		// the length of the month following monthDate, measured with the MonthEnd policy,
+		// e.g. January 31 to February 28 if clamped
+		monthEnd, err := opts.addDate(monthDate, 0, multiplier, 0)
+		if err != nil {
+			return 0, err
+		}
+		monthLength := monthEnd.Sub(monthDate).Abs()
+
+		return calculateFractionDuration(uint64(months.fraction), decimalPrecision, monthLength), nil
+	case FractionsNominal:
+		nominalYear := opts.NominalYear
+		if nominalYear <= 0 {
+			nominalYear = TimeYear
+		}
+		nominalMonth := opts.NominalMonth
+		if nominalMonth <= 0 {
+			nominalMonth = TimeMonth
+		}
+
+		// both fractions are smaller than their nominal length, thus the sum cannot overflow
+		return calculateFractionDuration(uint64(years.fraction), decimalPrecision, nominalYear) +
+			calculateFractionDuration(uint64(months.fraction), decimalPrecision, nominalMonth), nil
+	}
+
+	return 0, nil
+}
@@ -0,0 +1,432 @@
+package iso8601_test
+
+import (
+	"github.com/Achsion/iso8601/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+	_ "time/tzdata"
+)
+
+func mustLoadLocation(t require.TestingT, name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	require.NoError(t, err)
+
+	return loc
+}
+
+func TestDuration_AddToTimeWithOptions_Fractions(t *testing.T) {
+	testCases := []struct {
+		name     string
+		dur      string
+		opts     iso8601.AddOptions
+		stdTime  time.Time
+		expected time.Time
+	}{
+		{
+			name:     "zero options behave like AddToTime",
+			dur:      "P1Y1M1DT1H",
+			stdTime:  time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2025, 3, 4, 1, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "spread half month in february of a leap year",
+			dur:      "P0.5M",
+			opts:     iso8601.AddOptions{Fractions: iso8601.FractionsSpread},
+			stdTime:  time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2024, 2, 15, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "spread uses the length of the target month",
+			dur:      "P1.5M",
+			opts:     iso8601.AddOptions{Fractions: iso8601.FractionsSpread},
+			stdTime:  time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2024, 3, 16, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "spread month end overflows by default",
+			dur:      "P0.5M",
+			opts:     iso8601.AddOptions{Fractions: iso8601.FractionsSpread},
+			stdTime:  time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2023, 2, 15, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "spread month end clamped measures february",
+			dur:      "P0.5M",
+			opts:     iso8601.AddOptions{Fractions: iso8601.FractionsSpread, MonthEnd: iso8601.MonthEndClamp},
+			stdTime:  time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2023, 2, 14, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "spread month end clamped after whole months",
+			dur:      "P1.5M",
+			opts:     iso8601.AddOptions{Fractions: iso8601.FractionsSpread, MonthEnd: iso8601.MonthEndClamp},
+			stdTime:  time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2024, 2, 14, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "spread fractional year into whole months",
+			dur:      "P1.5Y",
+			opts:     iso8601.AddOptions{Fractions: iso8601.FractionsSpread},
+			stdTime:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "spread fractional year into months and days",
+			dur:      "P0.1Y",
+			opts:     iso8601.AddOptions{Fractions: iso8601.FractionsSpread},
+			stdTime:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2024, 2, 6, 19, 12, 0, 0, time.UTC),
+		},
+		{
+			name:     "spread negative uses the length of the previous month",
+			dur:      "-P0.5M",
+			opts:     iso8601.AddOptions{Fractions: iso8601.FractionsSpread},
+			stdTime:  time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2024, 2, 15, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "nominal year with default length",
+			dur:      "P1.5Y",
+			opts:     iso8601.AddOptions{Fractions: iso8601.FractionsNominal},
+			stdTime:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2025, 7, 2, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "nominal month with custom length",
+			dur:  "P0.5M",
+			opts: iso8601.AddOptions{
+				Fractions:    iso8601.FractionsNominal,
+				NominalMonth: iso8601.TimeGregorianMonth,
+			},
+			stdTime:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2024, 1, 16, 5, 14, 33, 0, time.UTC),
+		},
+		{
+			name:     "nominal negative",
+			dur:      "-P0.5M",
+			opts:     iso8601.AddOptions{Fractions: iso8601.FractionsNominal},
+			stdTime:  time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2024, 2, 15, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := mustDurationFromString(t, tc.dur).AddToTimeWithOptions(tc.stdTime, tc.opts)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestDuration_AddToTimeWithOptions_Fractions_Error(t *testing.T) {
+	testCases := []struct {
+		name string
+		dur  string
+		opts iso8601.AddOptions
+	}{
+		{
+			name: "fractional year without policy",
+			dur:  "P1.5Y",
+		},
+		{
+			name: "fractional month without policy",
+			dur:  "P1.5M",
+		},
+		{
+			name: "spread years exceed month capacity",
+			dur:  "P9223372036854775807Y",
+			opts: iso8601.AddOptions{Fractions: iso8601.FractionsSpread},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dateTime := time.Date(2025, 7, 7, 20, 26, 24, 0, time.UTC)
+			_, err := mustDurationFromString(t, tc.dur).AddToTimeWithOptions(dateTime, tc.opts)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestDuration_AddToTimeWithOptions_MonthEnd(t *testing.T) {
+	testCases := []struct {
+		name     string
+		dur      string
+		monthEnd iso8601.MonthEndPolicy
+		stdTime  time.Time
+		expected time.Time
+	}{
+		{
+			name:     "overflow into the following month",
+			dur:      "P1M",
+			monthEnd: iso8601.MonthEndOverflow,
+			stdTime:  time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "clamp to february",
+			dur:      "P1M",
+			monthEnd: iso8601.MonthEndClamp,
+			stdTime:  time.Date(2025, 1, 31, 10, 30, 0, 0, time.UTC),
+			expected: time.Date(2025, 2, 28, 10, 30, 0, 0, time.UTC),
+		},
+		{
+			name:     "clamp to february of a leap year",
+			dur:      "P1M",
+			monthEnd: iso8601.MonthEndClamp,
+			stdTime:  time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "clamp leap day to the following year",
+			dur:      "P1Y",
+			monthEnd: iso8601.MonthEndClamp,
+			stdTime:  time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "clamp before adding days",
+			dur:      "P1M1D",
+			monthEnd: iso8601.MonthEndClamp,
+			stdTime:  time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "clamp negative",
+			dur:      "-P1M",
+			monthEnd: iso8601.MonthEndClamp,
+			stdTime:  time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "clamp not needed",
+			dur:      "P1M",
+			monthEnd: iso8601.MonthEndClamp,
+			stdTime:  time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2025, 2, 15, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "error not needed",
+			dur:      "P1M",
+			monthEnd: iso8601.MonthEndError,
+			stdTime:  time.Date(2025, 1, 28, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := iso8601.AddOptions{MonthEnd: tc.monthEnd}
+			actual, err := mustDurationFromString(t, tc.dur).AddToTimeWithOptions(tc.stdTime, opts)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestDuration_AddToTimeWithOptions_MonthEnd_Error(t *testing.T) {
+	opts := iso8601.AddOptions{MonthEnd: iso8601.MonthEndError}
+
+	_, err := mustDurationFromString(t, "P1M").AddToTimeWithOptions(time.Date(2025, 1, 30, 0, 0, 0, 0, time.UTC), opts)
+	assert.ErrorIs(t, err, iso8601.ErrMonthEnd)
+
+	_, err = mustDurationFromString(t, "P1Y").AddToTimeWithOptions(time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), opts)
+	assert.ErrorIs(t, err, iso8601.ErrMonthEnd)
+
+	// the target month of a spread fraction does not contain the day either
+	opts.Fractions = iso8601.FractionsSpread
+	_, err = mustDurationFromString(t, "P0.5M").AddToTimeWithOptions(time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC), opts)
+	assert.ErrorIs(t, err, iso8601.ErrMonthEnd)
+}
+
+func TestDuration_AddToTimeWithOptions_Mode(t *testing.T) {
+	berlin := mustLoadLocation(t, "Europe/Berlin")
+	newYork := mustLoadLocation(t, "America/New_York")
+
+	testCases := []struct {
+		name     string
+		dur      string
+		opts     iso8601.AddOptions
+		stdTime  time.Time
+		expected time.Time
+	}{
+		{
+			name:     "mixed day keeps local time across dst start",
+			dur:      "P1D",
+			stdTime:  time.Date(2025, 3, 29, 12, 0, 0, 0, berlin),
+			expected: time.Date(2025, 3, 30, 12, 0, 0, 0, berlin),
+		},
+		{
+			name:     "mixed hours are elapsed across dst start",
+			dur:      "PT24H",
+			stdTime:  time.Date(2025, 3, 29, 12, 0, 0, 0, berlin),
+			expected: time.Date(2025, 3, 30, 13, 0, 0, 0, berlin),
+		},
+		{
+			name:     "nominal hours keep local time across dst start",
+			dur:      "PT24H",
+			opts:     iso8601.AddOptions{Mode: iso8601.AddModeNominal},
+			stdTime:  time.Date(2025, 3, 29, 12, 0, 0, 0, berlin),
+			expected: time.Date(2025, 3, 30, 12, 0, 0, 0, berlin),
+		},
+		{
+			name:     "nominal negative across dst end",
+			dur:      "-PT24H",
+			opts:     iso8601.AddOptions{Mode: iso8601.AddModeNominal},
+			stdTime:  time.Date(2025, 10, 27, 12, 0, 0, 0, berlin),
+			expected: time.Date(2025, 10, 26, 12, 0, 0, 0, berlin),
+		},
+		{
+			name:     "exact day is elapsed across dst start",
+			dur:      "P1D",
+			opts:     iso8601.AddOptions{Mode: iso8601.AddModeExact},
+			stdTime:  time.Date(2025, 3, 29, 12, 0, 0, 0, berlin),
+			expected: time.Date(2025, 3, 30, 13, 0, 0, 0, berlin),
+		},
+		{
+			name:     "exact day is elapsed across dst end",
+			dur:      "P1D",
+			opts:     iso8601.AddOptions{Mode: iso8601.AddModeExact},
+			stdTime:  time.Date(2025, 11, 1, 12, 0, 0, 0, newYork),
+			expected: time.Date(2025, 11, 2, 11, 0, 0, 0, newYork),
+		},
+		{
+			name:     "exact month follows the local calendar",
+			dur:      "P1M",
+			opts:     iso8601.AddOptions{Mode: iso8601.AddModeExact},
+			stdTime:  time.Date(2024, 3, 1, 0, 30, 0, 0, berlin),
+			expected: time.Date(2024, 4, 1, 0, 30, 0, 0, berlin),
+		},
+		{
+			name:     "exact year follows the local calendar",
+			dur:      "P1Y",
+			opts:     iso8601.AddOptions{Mode: iso8601.AddModeExact},
+			stdTime:  time.Date(2024, 2, 29, 21, 0, 0, 0, newYork),
+			expected: time.Date(2025, 3, 1, 21, 0, 0, 0, newYork),
+		},
+		{
+			name:     "exact month with month end policy",
+			dur:      "-P1M",
+			opts:     iso8601.AddOptions{Mode: iso8601.AddModeExact, MonthEnd: iso8601.MonthEndClamp},
+			stdTime:  time.Date(2024, 3, 31, 20, 0, 0, 0, newYork),
+			expected: time.Date(2024, 2, 29, 20, 0, 0, 0, newYork),
+		},
+		{
+			name:     "exact days are elapsed after the months",
+			dur:      "P1M2D",
+			opts:     iso8601.AddOptions{Mode: iso8601.AddModeExact},
+			stdTime:  time.Date(2024, 2, 29, 12, 0, 0, 0, berlin),
+			expected: time.Date(2024, 3, 31, 13, 0, 0, 0, berlin),
+		},
+		{
+			name:     "nonexistent compatible shifts forward",
+			dur:      "PT1H",
+			opts:     iso8601.AddOptions{Mode: iso8601.AddModeNominal, LocalTime: iso8601.LocalTimeCompatible},
+			stdTime:  time.Date(2025, 3, 30, 1, 30, 0, 0, berlin),
+			expected: time.Date(2025, 3, 30, 3, 30, 0, 0, berlin),
+		},
+		{
+			name:     "nonexistent later shifts forward",
+			dur:      "P1D",
+			opts:     iso8601.AddOptions{LocalTime: iso8601.LocalTimeLater},
+			stdTime:  time.Date(2025, 3, 8, 2, 30, 0, 0, newYork),
+			expected: time.Date(2025, 3, 9, 3, 30, 0, 0, newYork),
+		},
+		{
+			name:     "nonexistent earlier shifts backward",
+			dur:      "P1D",
+			opts:     iso8601.AddOptions{LocalTime: iso8601.LocalTimeEarlier},
+			stdTime:  time.Date(2025, 3, 29, 2, 30, 0, 0, berlin),
+			expected: time.Date(2025, 3, 30, 1, 30, 0, 0, berlin),
+		},
+		{
+			name:     "ambiguous default is like AddDate",
+			dur:      "P1D",
+			stdTime:  time.Date(2024, 10, 26, 2, 30, 0, 0, berlin),
+			expected: time.Date(2024, 10, 27, 1, 30, 0, 0, time.UTC).In(berlin),
+		},
+		{
+			name:     "ambiguous compatible is the earlier time",
+			dur:      "P1D",
+			opts:     iso8601.AddOptions{LocalTime: iso8601.LocalTimeCompatible},
+			stdTime:  time.Date(2024, 10, 26, 2, 30, 0, 0, berlin),
+			expected: time.Date(2024, 10, 27, 0, 30, 0, 0, time.UTC).In(berlin),
+		},
+		{
+			name:     "ambiguous earlier",
+			dur:      "PT1H",
+			opts:     iso8601.AddOptions{Mode: iso8601.AddModeNominal, LocalTime: iso8601.LocalTimeEarlier},
+			stdTime:  time.Date(2025, 10, 26, 1, 30, 0, 0, berlin),
+			expected: time.Date(2025, 10, 26, 0, 30, 0, 0, time.UTC).In(berlin),
+		},
+		{
+			name:     "ambiguous later",
+			dur:      "P1D",
+			opts:     iso8601.AddOptions{LocalTime: iso8601.LocalTimeLater},
+			stdTime:  time.Date(2025, 11, 1, 1, 30, 0, 0, newYork),
+			expected: time.Date(2025, 11, 2, 6, 30, 0, 0, time.UTC).In(newYork),
+		},
+		{
+			name:     "ambiguous start keeps its offset",
+			dur:      "PT1M",
+			opts:     iso8601.AddOptions{LocalTime: iso8601.LocalTimeReject},
+			stdTime:  time.Date(2025, 11, 2, 6, 30, 0, 0, time.UTC).In(newYork),
+			expected: time.Date(2025, 11, 2, 6, 31, 0, 0, time.UTC).In(newYork),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := mustDurationFromString(t, tc.dur).AddToTimeWithOptions(tc.stdTime, tc.opts)
+			require.NoError(t, err)
+			assert.True(t, tc.expected.Equal(actual), "expected %s, actual %s", tc.expected, actual)
+			assert.Equal(t, tc.expected.Location(), actual.Location())
+		})
+	}
+}
+
+func TestDuration_AddToTimeWithOptions_Mode_Error(t *testing.T) {
+	berlin := mustLoadLocation(t, "Europe/Berlin")
+	newYork := mustLoadLocation(t, "America/New_York")
+
+	testCases := []struct {
+		name    string
+		dur     string
+		mode    iso8601.AddMode
+		stdTime time.Time
+	}{
+		{
+			name:    "nonexistent mixed",
+			dur:     "P1D",
+			stdTime: time.Date(2025, 3, 29, 2, 30, 0, 0, berlin),
+		},
+		{
+			name:    "nonexistent nominal",
+			dur:     "PT1H",
+			mode:    iso8601.AddModeNominal,
+			stdTime: time.Date(2025, 3, 9, 1, 30, 0, 0, newYork),
+		},
+		{
+			name:    "ambiguous mixed",
+			dur:     "P1D",
+			stdTime: time.Date(2025, 11, 1, 1, 30, 0, 0, newYork),
+		},
+		{
+			name:    "ambiguous nominal",
+			dur:     "PT1H",
+			mode:    iso8601.AddModeNominal,
+			stdTime: time.Date(2025, 10, 26, 1, 30, 0, 0, berlin),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := iso8601.AddOptions{Mode: tc.mode, LocalTime: iso8601.LocalTimeReject}
+			_, err := mustDurationFromString(t, tc.dur).AddToTimeWithOptions(tc.stdTime, opts)
+			assert.ErrorIs(t, err, iso8601.ErrLocalTime)
+		})
+	}
+}
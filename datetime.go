@@ -0,0 +1,443 @@
+package iso8601
+
+import (
+	"time"
+)
+
+// FormatStyle selects between the basic and the extended format of ISO 8601 dates and times.
+type FormatStyle int
+
+const (
+	// FormatExtended separates the fields of a date with '-' and the fields of a time with ':',
+	// e.g. "2024-03-15T14:30:05Z".
+	FormatExtended FormatStyle = iota
+	// FormatBasic omits all separators, e.g. "20240315T143005Z".
+	FormatBasic
+)
+
+// TimePrecision defines the smallest field of a formatted time of day.
+type TimePrecision int
+
+const (
+	// PrecisionHour formats only the hour, e.g. "14Z".
+	PrecisionHour TimePrecision = iota
+	// PrecisionMinute formats hour and minute, e.g. "14:30Z".
+	PrecisionMinute
+	// PrecisionSecond formats hour, minute and second, e.g. "14:30:05Z".
+	PrecisionSecond
+	// PrecisionMillisecond additionally formats three fractional digits of the second, e.g. "14:30:05.123Z".
+	PrecisionMillisecond
+	// PrecisionMicrosecond additionally formats six fractional digits of the second, e.g. "14:30:05.123456Z".
+	PrecisionMicrosecond
+	// PrecisionNanosecond additionally formats nine fractional digits of the second, e.g. "14:30:05.123456789Z".
+	PrecisionNanosecond
+)
+
+// ParseDate parses an ISO 8601 calendar date in the extended format "YYYY-MM-DD" or the basic format "YYYYMMDD"
+// into a time.Time at midnight UTC.
+func ParseDate(dateString string) (time.Time, error) {
+	scanner := dateTimeScanner{input: dateString}
+
+	year, month, day, err := scanner.scanDate()
+	if err != nil {
+		return time.Time{}, err
+	}
+	if err := scanner.end(); err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC), nil
+}
+
+// ParseTime parses an ISO 8601 time of day, optionally prefixed with 'T', into a time.Time on January 1 of year 0.
+// It accepts the extended format "hh:mm:ss" and the basic format "hhmmss", where minutes and seconds may be omitted.
+// The lowest-order field may contain a decimal fraction, separated by either a full stop or a comma,
+// e.g. "14:30:05.123" or "14:30,5". The end of the day "24:00" results in midnight of January 2.
+//
+// The time may be followed by "Z" for UTC or an offset like "+05:30", "+0530" or "+05".
+// A time without either is interpreted as UTC, and offsets of zero result in time.UTC.
+func ParseTime(timeString string) (time.Time, error) {
+	scanner := dateTimeScanner{input: timeString}
+	if scanner.pos < len(timeString) && timeString[scanner.pos] == timeSwitchDesignator {
+		scanner.pos++
+	}
+
+	clock, loc, err := scanner.scanTime()
+	if err != nil {
+		return time.Time{}, err
+	}
+	if err := scanner.end(); err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Date(0, time.January, 1, 0, 0, 0, int(clock), loc), nil
+}
+
+// ParseDateTime parses an ISO 8601 calendar date and time of day separated by 'T', e.g. "2024-03-15T14:30:05.123Z"
+// or "20240315T143005,123+0530". Date and time must both use either the basic or the extended format.
+// The date follows the rules of ParseDate and the time follows the rules of ParseTime.
+func ParseDateTime(dateTimeString string) (time.Time, error) {
+	scanner := dateTimeScanner{input: dateTimeString}
+
+	year, month, day, err := scanner.scanDate()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if scanner.pos >= len(dateTimeString) || dateTimeString[scanner.pos] != timeSwitchDesignator {
+		return time.Time{}, newParseError(dateTimeString, scanner.pos, ReasonInvalidDesignator)
+	}
+	scanner.pos++
+
+	clock, loc, err := scanner.scanTime()
+	if err != nil {
+		return time.Time{}, err
+	}
+	if err := scanner.end(); err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Date(year, month, day, 0, 0, 0, int(clock), loc), nil
+}
+
+// FormatDate returns the ISO 8601 calendar date of t, e.g. "2024-03-15" or "20240315".
+// Years outside of 0000 to 9999 are prefixed with their sign, which cannot be parsed by ParseDate.
+func FormatDate(t time.Time, style FormatStyle) string {
+	var arr [32]byte
+
+	return string(appendDate(arr[:0], t, style))
+}
+
+// FormatTime returns the ISO 8601 time of day of t down to precision, followed by "Z" for UTC or the offset of t,
+// e.g. "14:30:05.123+05:30" or "143005.123+0530". Fields smaller than precision are truncated.
+func FormatTime(t time.Time, style FormatStyle, precision TimePrecision) string {
+	var arr [32]byte
+
+	return string(appendTime(arr[:0], t, style, precision))
+}
+
+// FormatDateTime returns the ISO 8601 calendar date and time of day of t separated by 'T',
+// e.g. "2024-03-15T14:30:05Z". It follows the rules of FormatDate and FormatTime.
+func FormatDateTime(t time.Time, style FormatStyle, precision TimePrecision) string {
+	var arr [64]byte
+	buf := appendDate(arr[:0], t, style)
+	buf = append(buf, timeSwitchDesignator)
+
+	return string(appendTime(buf, t, style, precision))
+}
+
+// appendDate appends the calendar date of t to buf.
+func appendDate(buf []byte, t time.Time, style FormatStyle) []byte {
+	year, month, day := t.Date()
+
+	buf = appendYear(buf, year)
+	if style == FormatExtended {
+		buf = append(buf, dateSeparator)
+	}
+	buf = appendInt(buf, int(month), 2)
+	if style == FormatExtended {
+		buf = append(buf, dateSeparator)
+	}
+
+	return appendInt(buf, day, 2)
+}
+
+// appendTime appends the time of day of t down to precision and its offset to buf.
+func appendTime(buf []byte, t time.Time, style FormatStyle, precision TimePrecision) []byte {
+	hour, minute, second := t.Clock()
+
+	buf = appendInt(buf, hour, 2)
+	if precision >= PrecisionMinute {
+		if style == FormatExtended {
+			buf = append(buf, timeSeparator)
+		}
+		buf = appendInt(buf, minute, 2)
+	}
+	if precision >= PrecisionSecond {
+		if style == FormatExtended {
+			buf = append(buf, timeSeparator)
+		}
+		buf = appendInt(buf, second, 2)
+	}
+	if precision > PrecisionSecond {
+		digits := 3 * int(min(precision, PrecisionNanosecond)-PrecisionSecond)
+		buf = append(buf, decimalPointDesignator)
+		buf = appendInt(buf, t.Nanosecond()/int(pow10[9-digits]), digits)
+	}
+
+	return appendOffset(buf, t, style)
+}
+
+// appendOffset appends "Z" for UTC or the offset of t in hours and minutes to buf.
+func appendOffset(buf []byte, t time.Time, style FormatStyle) []byte {
+	_, offset := t.Zone()
+	if offset == 0 {
+		return append(buf, utcDesignator)
+	}
+
+	if offset < 0 {
+		buf = append(buf, '-')
+		offset = -offset
+	} else {
+		buf = append(buf, '+')
+	}
+
+	buf = appendInt(buf, offset/3600, 2)
+	if style == FormatExtended {
+		buf = append(buf, timeSeparator)
+	}
+
+	return appendInt(buf, offset/60%60, 2)
+}
+
+// appendYear appends the year zero-padded to four digits to buf.
+// Years outside of 0000 to 9999 are prefixed with their sign.
+func appendYear(buf []byte, year int) []byte {
+	switch {
+	case year < 0:
+		buf = append(buf, '-')
+		year = -year
+	case year > 9999:
+		buf = append(buf, '+')
+	}
+
+	return appendInt(buf, year, 4)
+}
+
+// appendInt appends the non-negative value zero-padded to width digits to buf.
+func appendInt(buf []byte, value, width int) []byte {
+	var arr [20]byte
+	idx := fmtInt(arr[:], uint64(value))
+	for i := len(arr) - idx; i < width; i++ {
+		buf = append(buf, '0')
+	}
+
+	return append(buf, arr[idx:]...)
+}
+
+// isLeapYear reports whether year is a leap year in the proleptic Gregorian calendar.
+func isLeapYear(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
+
+// daysInMonth returns the number of days of month in year.
+func daysInMonth(year int, month time.Month) int {
+	switch month {
+	case time.February:
+		if isLeapYear(year) {
+			return 29
+		}
+
+		return 28
+	case time.April, time.June, time.September, time.November:
+		return 30
+	}
+
+	return 31
+}
+
+// dateTimeScanner scans ISO 8601 dates and times in a single pass and validates their format.
+// It never allocates, except for returned errors and time zones with an offset other than zero.
+type dateTimeScanner struct {
+	input string
+	pos   int
+
+	// style is the format of the first field separator that has been scanned, if hasStyle is set
+	style    FormatStyle
+	hasStyle bool
+}
+
+// digits scans a field of exactly n digits.
+func (s *dateTimeScanner) digits(n int) (int, error) {
+	value := 0
+	for range n {
+		if s.pos >= len(s.input) || !isDigit(s.input[s.pos]) {
+			return 0, newParseError(s.input, s.pos, ReasonMissingDigits)
+		}
+		value = value*10 + int(s.input[s.pos]-'0')
+		s.pos++
+	}
+
+	return value, nil
+}
+
+// separator consumes the separator of the extended format if present,
+// and validates that the basic and the extended format are not mixed.
+func (s *dateTimeScanner) separator(separator byte) error {
+	style := FormatBasic
+	if s.pos < len(s.input) && s.input[s.pos] == separator {
+		style = FormatExtended
+	}
+
+	if s.hasStyle && style != s.style {
+		return newParseError(s.input, s.pos, ReasonMixedFormats)
+	}
+	s.style = style
+	s.hasStyle = true
+
+	if style == FormatExtended {
+		s.pos++
+	}
+
+	return nil
+}
+
+// end validates that the complete input has been scanned.
+func (s *dateTimeScanner) end() error {
+	if s.pos < len(s.input) {
+		return newParseError(s.input, s.pos, ReasonInvalidDesignator)
+	}
+
+	return nil
+}
+
+// scanDate scans a calendar date "YYYY-MM-DD" or "YYYYMMDD".
+func (s *dateTimeScanner) scanDate() (year int, month time.Month, day int, err error) {
+	year, err = s.digits(4)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	if err := s.separator(dateSeparator); err != nil {
+		return 0, 0, 0, err
+	}
+	monthOffset := s.pos
+	monthValue, err := s.digits(2)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	if monthValue < 1 || monthValue > 12 {
+		return 0, 0, 0, newParseError(s.input, monthOffset, ReasonFieldRange)
+	}
+	month = time.Month(monthValue)
+
+	if err := s.separator(dateSeparator); err != nil {
+		return 0, 0, 0, err
+	}
+	dayOffset := s.pos
+	day, err = s.digits(2)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	if day < 1 || day > daysInMonth(year, month) {
+		return 0, 0, 0, newParseError(s.input, dayOffset, ReasonFieldRange)
+	}
+
+	return year, month, day, nil
+}
+
+// scanTime scans a time of day "hh[:mm[:ss]][.fraction][zone]" and returns it as duration since midnight.
+// The duration only reaches a whole day for the end of the day "24:00".
+func (s *dateTimeScanner) scanTime() (time.Duration, *time.Location, error) {
+	hourOffset := s.pos
+	hour, err := s.digits(2)
+	if err != nil {
+		return 0, nil, err
+	}
+	clock := time.Duration(hour) * time.Hour
+
+	// the unit of the lowest-order field, which the decimal fraction refers to
+	unit := time.Hour
+	for unit > time.Second && s.pos < len(s.input) && (s.input[s.pos] == timeSeparator || isDigit(s.input[s.pos])) {
+		if err := s.separator(timeSeparator); err != nil {
+			return 0, nil, err
+		}
+
+		fieldOffset := s.pos
+		value, err := s.digits(2)
+		if err != nil {
+			return 0, nil, err
+		}
+		if value > 59 {
+			// leap seconds cannot be represented by time.Time
+			return 0, nil, newParseError(s.input, fieldOffset, ReasonFieldRange)
+		}
+
+		unit /= 60
+		clock += time.Duration(value) * unit
+	}
+
+	if s.pos < len(s.input) && isDecimalSeparator(s.input[s.pos]) {
+		s.pos++
+
+		fractionStart := s.pos
+		var fraction uint64
+		fractionDigits := 0
+		for s.pos < len(s.input) && isDigit(s.input[s.pos]) {
+			if fractionDigits < maxFractionDigits {
+				fraction = fraction*10 + uint64(s.input[s.pos]-'0')
+				fractionDigits++
+			}
+			s.pos++
+		}
+		if s.pos == fractionStart {
+			return 0, nil, newParseError(s.input, s.pos, ReasonInvalidFraction)
+		}
+
+		clock += calculateFractionDuration(fraction, fractionDigits, unit)
+	}
+
+	if hour > 24 || (hour == 24 && clock != TimeDay) {
+		return 0, nil, newParseError(s.input, hourOffset, ReasonFieldRange)
+	}
+
+	loc, err := s.scanZone()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return clock, loc, nil
+}
+
+// scanZone scans the optional zone designator "Z" or an offset "+hh[:mm]" / "-hh[:mm]".
+func (s *dateTimeScanner) scanZone() (*time.Location, error) {
+	if s.pos >= len(s.input) {
+		return time.UTC, nil
+	}
+
+	sign := 1
+	switch s.input[s.pos] {
+	case utcDesignator:
+		s.pos++
+
+		return time.UTC, nil
+	case '+':
+	case '-':
+		sign = -1
+	default:
+		return nil, newParseError(s.input, s.pos, ReasonInvalidDesignator)
+	}
+	s.pos++
+
+	hourOffset := s.pos
+	hours, err := s.digits(2)
+	if err != nil {
+		return nil, err
+	}
+	if hours > 23 {
+		return nil, newParseError(s.input, hourOffset, ReasonFieldRange)
+	}
+
+	minutes := 0
+	if s.pos < len(s.input) {
+		if err := s.separator(timeSeparator); err != nil {
+			return nil, err
+		}
+
+		minuteOffset := s.pos
+		minutes, err = s.digits(2)
+		if err != nil {
+			return nil, err
+		}
+		if minutes > 59 {
+			return nil, newParseError(s.input, minuteOffset, ReasonFieldRange)
+		}
+	}
+
+	offset := sign * (hours*3600 + minutes*60)
+	if offset == 0 {
+		return time.UTC, nil
+	}
+
+	return time.FixedZone("", offset), nil
+}
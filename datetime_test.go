@@ -0,0 +1,243 @@
+package iso8601_test
+
+import (
+	"github.com/Achsion/iso8601/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected time.Time
+	}{
+		{input: "2024-03-15", expected: time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)},
+		{input: "20240315", expected: time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)},
+		{input: "2024-02-29", expected: time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{input: "2000-02-29", expected: time.Date(2000, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{input: "0000-01-01", expected: time.Date(0, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{input: "99991231", expected: time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			actual, err := iso8601.ParseDate(tc.input)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	kolkata := time.FixedZone("", 5*3600+30*60)
+	newYork := time.FixedZone("", -5*3600)
+
+	testCases := []struct {
+		input    string
+		expected time.Time
+	}{
+		{input: "T14:30:05.123", expected: time.Date(0, 1, 1, 14, 30, 5, 123000000, time.UTC)},
+		{input: "14:30:05,123", expected: time.Date(0, 1, 1, 14, 30, 5, 123000000, time.UTC)},
+		{input: "143005.123", expected: time.Date(0, 1, 1, 14, 30, 5, 123000000, time.UTC)},
+		{input: "14:30Z", expected: time.Date(0, 1, 1, 14, 30, 0, 0, time.UTC)},
+		{input: "T1430Z", expected: time.Date(0, 1, 1, 14, 30, 0, 0, time.UTC)},
+		{input: "14", expected: time.Date(0, 1, 1, 14, 0, 0, 0, time.UTC)},
+		{input: "14.5", expected: time.Date(0, 1, 1, 14, 30, 0, 0, time.UTC)},
+		{input: "14:30,5", expected: time.Date(0, 1, 1, 14, 30, 30, 0, time.UTC)},
+		{input: "00:00:00.000000001", expected: time.Date(0, 1, 1, 0, 0, 0, 1, time.UTC)},
+		{input: "24:00", expected: time.Date(0, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{input: "24:00:00.000", expected: time.Date(0, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{input: "14:30+05:30", expected: time.Date(0, 1, 1, 14, 30, 0, 0, kolkata)},
+		{input: "1430+0530", expected: time.Date(0, 1, 1, 14, 30, 0, 0, kolkata)},
+		{input: "14:30-05", expected: time.Date(0, 1, 1, 14, 30, 0, 0, newYork)},
+		{input: "14:30+00:00", expected: time.Date(0, 1, 1, 14, 30, 0, 0, time.UTC)},
+		{input: "14:30-00:00", expected: time.Date(0, 1, 1, 14, 30, 0, 0, time.UTC)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			actual, err := iso8601.ParseTime(tc.input)
+			require.NoError(t, err)
+			assert.True(t, tc.expected.Equal(actual), "expected %s, actual %s", tc.expected, actual)
+
+			_, expectedOffset := tc.expected.Zone()
+			_, actualOffset := actual.Zone()
+			assert.Equal(t, expectedOffset, actualOffset)
+		})
+	}
+}
+
+func TestParseDateTime(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected time.Time
+	}{
+		{input: "2024-03-15T14:30:05.123Z", expected: time.Date(2024, 3, 15, 14, 30, 5, 123000000, time.UTC)},
+		{input: "20240315T143005Z", expected: time.Date(2024, 3, 15, 14, 30, 5, 0, time.UTC)},
+		{input: "2024-03-15T14:30", expected: time.Date(2024, 3, 15, 14, 30, 0, 0, time.UTC)},
+		{input: "2024-03-15T24:00", expected: time.Date(2024, 3, 16, 0, 0, 0, 0, time.UTC)},
+		{input: "2024-12-31T24:00Z", expected: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{
+			input:    "2024-03-15T14:30:05+05:30",
+			expected: time.Date(2024, 3, 15, 9, 0, 5, 0, time.UTC),
+		},
+		{
+			input:    "20240315T143005,5-0800",
+			expected: time.Date(2024, 3, 15, 22, 30, 5, 500000000, time.UTC),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			actual, err := iso8601.ParseDateTime(tc.input)
+			require.NoError(t, err)
+			assert.True(t, tc.expected.Equal(actual), "expected %s, actual %s", tc.expected, actual)
+		})
+	}
+}
+
+func TestParseDateTime_Error(t *testing.T) {
+	testCases := []struct {
+		name           string
+		parse          func(string) (time.Time, error)
+		input          string
+		expectedOffset int
+		expectedReason iso8601.ParseErrorReason
+	}{
+		{"empty date", iso8601.ParseDate, "", 0, iso8601.ReasonMissingDigits},
+		{"short year", iso8601.ParseDate, "24-03-15", 2, iso8601.ReasonMissingDigits},
+		{"short day", iso8601.ParseDate, "2024-03-1", 9, iso8601.ReasonMissingDigits},
+		{"month zero", iso8601.ParseDate, "2024-00-15", 5, iso8601.ReasonFieldRange},
+		{"month 13", iso8601.ParseDate, "20241315", 4, iso8601.ReasonFieldRange},
+		{"day zero", iso8601.ParseDate, "2024-03-00", 8, iso8601.ReasonFieldRange},
+		{"february 29 in non-leap year", iso8601.ParseDate, "2023-02-29", 8, iso8601.ReasonFieldRange},
+		{"february 29 in century", iso8601.ParseDate, "1900-02-29", 8, iso8601.ReasonFieldRange},
+		{"april 31", iso8601.ParseDate, "2024-04-31", 8, iso8601.ReasonFieldRange},
+		{"mixed date formats", iso8601.ParseDate, "2024-0315", 7, iso8601.ReasonMixedFormats},
+		{"mixed date formats basic first", iso8601.ParseDate, "202403-15", 6, iso8601.ReasonMixedFormats},
+		{"trailing characters", iso8601.ParseDate, "2024-03-15x", 10, iso8601.ReasonInvalidDesignator},
+		{"empty time", iso8601.ParseTime, "T", 1, iso8601.ReasonMissingDigits},
+		{"single digit hour", iso8601.ParseTime, "1:30", 1, iso8601.ReasonMissingDigits},
+		{"hour 25", iso8601.ParseTime, "25:00", 0, iso8601.ReasonFieldRange},
+		{"24 with minutes", iso8601.ParseTime, "24:01", 0, iso8601.ReasonFieldRange},
+		{"24 with fraction", iso8601.ParseTime, "24:00:00.1", 0, iso8601.ReasonFieldRange},
+		{"minute 60", iso8601.ParseTime, "14:60", 3, iso8601.ReasonFieldRange},
+		{"leap second", iso8601.ParseTime, "23:59:60", 6, iso8601.ReasonFieldRange},
+		{"mixed time formats", iso8601.ParseTime, "14:3005", 5, iso8601.ReasonMixedFormats},
+		{"mixed offset format", iso8601.ParseTime, "14:30+0530", 8, iso8601.ReasonMixedFormats},
+		{"fraction without digits", iso8601.ParseTime, "14:30.", 6, iso8601.ReasonInvalidFraction},
+		{"too many fields", iso8601.ParseTime, "1430051", 6, iso8601.ReasonInvalidDesignator},
+		{"invalid zone", iso8601.ParseTime, "14:30UTC", 5, iso8601.ReasonInvalidDesignator},
+		{"offset hour 24", iso8601.ParseTime, "14:30+24:00", 6, iso8601.ReasonFieldRange},
+		{"offset minute 60", iso8601.ParseTime, "14:30+05:60", 9, iso8601.ReasonFieldRange},
+		{"short offset", iso8601.ParseTime, "14:30+5", 7, iso8601.ReasonMissingDigits},
+		{"trailing after zone", iso8601.ParseTime, "14:30Zx", 6, iso8601.ReasonInvalidDesignator},
+		{"missing time", iso8601.ParseDateTime, "2024-03-15", 10, iso8601.ReasonInvalidDesignator},
+		{"space separator", iso8601.ParseDateTime, "2024-03-15 14:30", 10, iso8601.ReasonInvalidDesignator},
+		{"mixed date time formats", iso8601.ParseDateTime, "2024-03-15T1430", 13, iso8601.ReasonMixedFormats},
+		{"mixed basic date extended time", iso8601.ParseDateTime, "20240315T14:30", 11, iso8601.ReasonMixedFormats},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.parse(tc.input)
+
+			var parseErr *iso8601.ParseError
+			require.ErrorAs(t, err, &parseErr)
+			assert.Equal(t, tc.input, parseErr.Input)
+			assert.Equal(t, tc.expectedOffset, parseErr.Offset)
+			assert.Equal(t, tc.expectedReason, parseErr.Reason)
+		})
+	}
+}
+
+func TestParseDateTime_NoAllocations(t *testing.T) {
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = iso8601.ParseDateTime("2024-03-15T14:30:05.123Z")
+	})
+	assert.Zero(t, allocs)
+}
+
+func TestFormatDate(t *testing.T) {
+	testCases := []struct {
+		name     string
+		stdTime  time.Time
+		style    iso8601.FormatStyle
+		expected string
+	}{
+		{"extended", time.Date(2024, 3, 15, 14, 30, 0, 0, time.UTC), iso8601.FormatExtended, "2024-03-15"},
+		{"basic", time.Date(2024, 3, 15, 14, 30, 0, 0, time.UTC), iso8601.FormatBasic, "20240315"},
+		{"padded year", time.Date(33, 1, 2, 0, 0, 0, 0, time.UTC), iso8601.FormatExtended, "0033-01-02"},
+		{"year after 9999", time.Date(10000, 1, 2, 0, 0, 0, 0, time.UTC), iso8601.FormatExtended, "+10000-01-02"},
+		{"negative year", time.Date(-1, 1, 2, 0, 0, 0, 0, time.UTC), iso8601.FormatExtended, "-0001-01-02"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, iso8601.FormatDate(tc.stdTime, tc.style))
+		})
+	}
+}
+
+func TestFormatTime(t *testing.T) {
+	stdTime := time.Date(2024, 3, 15, 14, 30, 5, 123456789, time.UTC)
+	kolkata := time.Date(2024, 3, 15, 14, 30, 5, 120000000, time.FixedZone("IST", 5*3600+30*60))
+	newYork := time.Date(2024, 3, 15, 4, 3, 0, 0, time.FixedZone("EST", -5*3600))
+
+	testCases := []struct {
+		name      string
+		stdTime   time.Time
+		style     iso8601.FormatStyle
+		precision iso8601.TimePrecision
+		expected  string
+	}{
+		{"hour", stdTime, iso8601.FormatExtended, iso8601.PrecisionHour, "14Z"},
+		{"minute", stdTime, iso8601.FormatExtended, iso8601.PrecisionMinute, "14:30Z"},
+		{"second", stdTime, iso8601.FormatExtended, iso8601.PrecisionSecond, "14:30:05Z"},
+		{"millisecond", stdTime, iso8601.FormatExtended, iso8601.PrecisionMillisecond, "14:30:05.123Z"},
+		{"microsecond", stdTime, iso8601.FormatExtended, iso8601.PrecisionMicrosecond, "14:30:05.123456Z"},
+		{"nanosecond", stdTime, iso8601.FormatExtended, iso8601.PrecisionNanosecond, "14:30:05.123456789Z"},
+		{"basic", stdTime, iso8601.FormatBasic, iso8601.PrecisionMillisecond, "143005.123Z"},
+		{"keeps trailing zeros", kolkata, iso8601.FormatExtended, iso8601.PrecisionMillisecond, "14:30:05.120+05:30"},
+		{"basic offset", kolkata, iso8601.FormatBasic, iso8601.PrecisionSecond, "143005+0530"},
+		{"negative offset", newYork, iso8601.FormatExtended, iso8601.PrecisionMinute, "04:03-05:00"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, iso8601.FormatTime(tc.stdTime, tc.style, tc.precision))
+		})
+	}
+}
+
+func TestFormatDateTime(t *testing.T) {
+	stdTime := time.Date(2024, 3, 15, 14, 30, 5, 123000000, time.FixedZone("IST", 5*3600+30*60))
+
+	assert.Equal(t, "2024-03-15T14:30:05.123+05:30",
+		iso8601.FormatDateTime(stdTime, iso8601.FormatExtended, iso8601.PrecisionMillisecond))
+	assert.Equal(t, "20240315T1430+0530", iso8601.FormatDateTime(stdTime, iso8601.FormatBasic, iso8601.PrecisionMinute))
+}
+
+func TestFormatDateTime_RoundTrip(t *testing.T) {
+	testCases := []string{
+		"2024-03-15T14:30:05.123456789Z",
+		"20240315T143005.123456789Z",
+		"2024-02-29T00:00:00.000000001-08:00",
+		"0001-01-01T23:59:59.999999999+14:00",
+	}
+
+	for _, input := range testCases {
+		t.Run(input, func(t *testing.T) {
+			parsed, err := iso8601.ParseDateTime(input)
+			require.NoError(t, err)
+
+			style := iso8601.FormatExtended
+			if input[4] != '-' {
+				style = iso8601.FormatBasic
+			}
+			assert.Equal(t, input, iso8601.FormatDateTime(parsed, style, iso8601.PrecisionNanosecond))
+		})
+	}
+}
@@ -0,0 +1,225 @@
+package iso8601
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"math/bits"
+	"strconv"
+)
+
+// decimalPrecision is the number of fractional digits a decimal can hold exactly.
+const decimalPrecision = 9
+
+// decimalFractionUnit is the value of a decimal fraction of 1.
+const decimalFractionUnit = 1_000_000_000
+
+// decimal is an exact non-negative decimal number with up to 9 fractional digits.
+// It is used to store the values of a Duration without any floating point noise.
+type decimal struct {
+	whole    uint64
+	fraction uint32 // in units of 1e-9, always < decimalFractionUnit
+}
+
+// decimalFromComponent creates a decimal from the integer part value and the decimal fraction
+// fraction/10**fractionDigits. Fraction digits beyond decimalPrecision are truncated.
+func decimalFromComponent(value, fraction uint64, fractionDigits int) decimal {
+	if fractionDigits > decimalPrecision {
+		fraction /= pow10[fractionDigits-decimalPrecision]
+	} else {
+		fraction *= pow10[decimalPrecision-fractionDigits]
+	}
+
+	return decimal{whole: value, fraction: uint32(fraction)}
+}
+
+// decimalFromFloat64 creates a decimal from the shortest decimal representation of the float64 value,
+// so that e.g. 0.1 results in exactly 0.1. Values with more than 9 fractional digits, e.g. 1.0/3, are rounded
+// to 9 fractional digits.
+func decimalFromFloat64(value float64) (decimal, error) {
+	if value < 0 || math.IsNaN(value) || math.IsInf(value, 0) {
+		return decimal{}, errors.New("value must be a finite number greater than or equal to zero")
+	}
+	if value >= math.MaxUint64 {
+		return decimal{}, errors.New("value exceeds the supported range")
+	}
+
+	// the value is below 2**64, thus the whole part of its shortest representation fits into an uint64
+	buf := [64]byte{}
+	formatted := strconv.AppendFloat(buf[:0], value, 'f', -1, 64)
+
+	var whole, fraction uint64
+	fractionDigits := 0
+	isFraction := false
+	for _, char := range formatted {
+		switch {
+		case char == decimalPointDesignator:
+			isFraction = true
+		case !isFraction:
+			whole = whole*10 + uint64(char-'0')
+		case fractionDigits < decimalPrecision:
+			fraction = fraction*10 + uint64(char-'0')
+			fractionDigits++
+		default:
+			// the shortest representation has more fractional digits than a decimal can hold
+			out, _ := decimalFromFloat64Rounded(value) // cannot fail, as the value is in range
+
+			return out, nil
+		}
+	}
+
+	return decimalFromComponent(whole, fraction, fractionDigits), nil
+}
+
+// decimalFromFloat64Rounded creates a decimal from the non-negative float64 value rounded to 9 fractional digits.
+// It reports false if the value is not finite or exceeds the supported range.
+func decimalFromFloat64Rounded(value float64) (decimal, bool) {
+	if value < 0 || math.IsNaN(value) || value >= math.MaxUint64 {
+		return decimal{}, false
+	}
+
+	whole, fraction := math.Modf(value)
+	out := decimal{whole: uint64(whole)}
+
+	roundedFraction := uint32(math.Round(fraction * decimalFractionUnit))
+	if roundedFraction == decimalFractionUnit {
+		// the fraction has been rounded up to the next whole number
+		return out.add(decimal{whole: 1})
+	}
+	out.fraction = roundedFraction
+
+	return out, true
+}
+
+// isZero reports whether x is zero.
+func (x decimal) isZero() bool {
+	return x.whole == 0 && x.fraction == 0
+}
+
+// hasFraction reports whether x has a decimal fraction.
+func (x decimal) hasFraction() bool {
+	return x.fraction != 0
+}
+
+// add returns x + y. It reports false if the result exceeds the supported range.
+func (x decimal) add(y decimal) (decimal, bool) {
+	fraction := x.fraction + y.fraction // cannot overflow, as both are < 1e9
+	carry := uint64(0)
+	if fraction >= decimalFractionUnit {
+		fraction -= decimalFractionUnit
+		carry = 1
+	}
+
+	whole, overflow := bits.Add64(x.whole, y.whole, carry)
+
+	return decimal{whole: whole, fraction: fraction}, overflow == 0
+}
+
+// sub returns x - y. It must only be called if x >= y.
+func (x decimal) sub(y decimal) decimal {
+	fraction := x.fraction
+	borrow := uint64(0)
+	if fraction < y.fraction {
+		fraction += decimalFractionUnit
+		borrow = 1
+	}
+
+	return decimal{whole: x.whole - y.whole - borrow, fraction: fraction - y.fraction}
+}
+
+// cmp returns -1 if x < y, 0 if x == y and +1 if x > y.
+func (x decimal) cmp(y decimal) int {
+	switch {
+	case x.whole < y.whole:
+		return -1
+	case x.whole > y.whole:
+		return 1
+	case x.fraction < y.fraction:
+		return -1
+	case x.fraction > y.fraction:
+		return 1
+	}
+
+	return 0
+}
+
+// mul returns x * factor. It reports false if the result exceeds the supported range.
+func (x decimal) mul(factor uint64) (decimal, bool) {
+	// the product of the fraction is < 1e9 * factor, thus the quotient always fits into an uint64
+	hi, lo := bits.Mul64(uint64(x.fraction), factor)
+	carry, fraction := bits.Div64(hi, lo, decimalFractionUnit)
+
+	hi, whole := bits.Mul64(x.whole, factor)
+	if hi != 0 {
+		return decimal{}, false
+	}
+	whole, overflow := bits.Add64(whole, carry, 0)
+
+	return decimal{whole: whole, fraction: uint32(fraction)}, overflow == 0
+}
+
+// nanoUnits returns x in units of 1e-9.
+func (x decimal) nanoUnits() *big.Int {
+	out := new(big.Int).SetUint64(x.whole)
+	out.Mul(out, big.NewInt(decimalFractionUnit))
+
+	return out.Add(out, big.NewInt(int64(x.fraction)))
+}
+
+// spreadFraction returns the fraction of x converted into a unit that fits factor times into the unit of x,
+// e.g. the hours of the fraction of a day with a factor of 24.
+func (x decimal) spreadFraction(factor uint64) decimal {
+	scaled := uint64(x.fraction) * factor
+
+	return decimal{whole: scaled / decimalFractionUnit, fraction: uint32(scaled % decimalFractionUnit)}
+}
+
+// float64 returns the float64 closest to x.
+func (x decimal) float64() float64 {
+	if x.whole < (1<<53)/decimalFractionUnit {
+		// numerator and denominator are exact, thus the division is correctly rounded
+		return float64(x.whole*decimalFractionUnit+uint64(x.fraction)) / decimalFractionUnit
+	}
+
+	return float64(x.whole) + float64(x.fraction)/decimalFractionUnit
+}
+
+// int returns the whole part of x as int. It fails if x has a decimal fraction or exceeds the int range.
+func (x decimal) int() (int, error) {
+	if x.hasFraction() {
+		return 0, errors.New("decimal containing fraction not supported")
+	}
+	if x.whole > math.MaxInt {
+		return 0, errors.New("decimal val exceeds integer capacity")
+	}
+
+	return int(x.whole), nil
+}
+
+// appendTo appends the shortest decimal representation of x to buf, using separator for the decimal fraction.
+func (x decimal) appendTo(buf []byte, separator DecimalSeparator) []byte {
+	buf = strconv.AppendUint(buf, x.whole, 10)
+	if x.fraction == 0 {
+		return buf
+	}
+
+	if separator != DecimalComma {
+		separator = DecimalPoint
+	}
+	buf = append(buf, byte(separator))
+
+	digits := [decimalPrecision]byte{}
+	fraction := x.fraction
+	length := 0
+	for i := decimalPrecision - 1; i >= 0; i-- {
+		digit := fraction % 10
+		if length == 0 && digit != 0 {
+			// omit trailing zeros
+			length = i + 1
+		}
+		digits[i] = byte(digit) + '0'
+		fraction /= 10
+	}
+
+	return append(buf, digits[:length]...)
+}
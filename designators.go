@@ -12,8 +12,25 @@ const (
 	dayDesignator   = 'D'
 
 	// time
-	hourDesignator        = 'H'
-	minuteDesignator      = 'M'
-	secondDesignator      = 'S'
-	secondCommaDesignator = '.'
+	hourDesignator   = 'H'
+	minuteDesignator = 'M'
+	secondDesignator = 'S'
+
+	// date and time of day
+	dateSeparator = '-'
+	timeSeparator = ':'
+	utcDesignator = 'Z'
+
+	// intervals, ISO 8601 prefers the solidus but allows a double hyphen
+	intervalSeparator          = '/'
+	intervalAlternateSeparator = "--"
+	recurrenceDesignator       = 'R'
+
+	// decimal separators, ISO 8601 allows both but prefers the comma
+	decimalPointDesignator = '.'
+	decimalCommaDesignator = ','
 )
+
+func isDecimalSeparator(char byte) bool {
+	return char == decimalPointDesignator || char == decimalCommaDesignator
+}
@@ -2,10 +2,8 @@ package iso8601
 
 import (
 	"errors"
-	"fmt"
 	"math"
-	"regexp"
-	"strconv"
+	"math/big"
 	"time"
 )
 
@@ -13,141 +11,128 @@ import (
 type Duration struct {
 	isPositive bool
 
-	years   float64
-	months  float64
-	weeks   float64
-	days    float64
-	hours   float64
-	minutes float64
-	seconds float64
+	// all values are stored as exact decimals with up to 9 fractional digits
+	years   decimal
+	months  decimal
+	weeks   decimal
+	days    decimal
+	hours   decimal
+	minutes decimal
+	seconds decimal
 }
 
 // NewDuration creates a new Duration instance with the specified time units.
 // All unit values must be non-negative numbers.
+// The values are stored exactly as their shortest decimal representation, e.g. 0.1 is stored as exactly 0.1,
+// values with more than 9 fractional digits are rounded to 9 fractional digits.
 func NewDuration(isPositive bool, years, months, weeks, days, hours, minutes, seconds float64) (Duration, error) {
 	if years < 0 || months < 0 || weeks < 0 || days < 0 || hours < 0 || minutes < 0 || seconds < 0 {
 		return Duration{}, errors.New("all unit values must be greater than or equal to zero")
 	}
 
-	return Duration{
-		isPositive: isPositive,
-		years:      years,
-		months:     months,
-		weeks:      weeks,
-		days:       days,
-		hours:      hours,
-		minutes:    minutes,
-		seconds:    seconds,
-	}, nil
+	out := Duration{isPositive: isPositive}
+
+	values := [...]float64{
+		yearUnit:   years,
+		monthUnit:  months,
+		weekUnit:   weeks,
+		dayUnit:    days,
+		hourUnit:   hours,
+		minuteUnit: minutes,
+		secondUnit: seconds,
+	}
+	for unit := yearUnit; unit <= secondUnit; unit++ {
+		value, err := decimalFromFloat64(values[unit])
+		if err != nil {
+			return Duration{}, err
+		}
+
+		*out.unitValue(unit) = value
+	}
+
+	return out, nil
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 // Parsing /////////////////////////////////////////////////////////////////////////////////////////////////////////////
 
-const (
-	negativePatternKey = "negative"
-	yearsPatternKey    = "year"
-	monthsPatternKey   = "month"
-	weeksPatternKey    = "week"
-	daysPatternKey     = "day"
-	hoursPatternKey    = "hour"
-	minutesPatternKey  = "minute"
-	secondsPatternKey  = "second"
-)
-
-var durationRegex = regexp.MustCompile(
-	fmt.Sprintf(
-		`^((?P<%s>\-))?P((?P<%s>[0-9.]+)Y)?((?P<%s>[0-9.]+)M)?((?P<%s>[0-9.]+)W)?((?P<%s>[0-9.]+)D)?(T((?P<%s>[0-9.]+)H)?((?P<%s>[0-9.]+)M)?((?P<%s>[\d.]+)S)?)?$`,
-		negativePatternKey, yearsPatternKey, monthsPatternKey, weeksPatternKey, daysPatternKey, hoursPatternKey, minutesPatternKey, secondsPatternKey,
-	),
-)
-
 // DurationFromString parses an ISO 8601 duration string and creates an iso8601 Duration struct.
+// It accepts negative durations by prepending a '-' like: "[-]P<duration>".
+// Decimal fractions may be separated by either a full stop or a comma, e.g. "PT1.5S" or "PT1,5S".
+// Fraction digits after the 9th are truncated.
 func DurationFromString(iso8601DurationStr string) (Duration, error) {
-	// Implemented with regex for now, as speed should not be of major importance when using this func.
-	// If speed is crucial, `iso8601.ParseToDuration` should be used.
-	// This implementation will probably be changed to something faster, but regex should suffice for now.
-
-	matches, err := findStringCaptureGroupMatches(durationRegex, iso8601DurationStr)
+	scanner, err := newDurationScanner(iso8601DurationStr)
 	if err != nil {
 		return Duration{}, err
 	}
 
-	out := Duration{}
+	out := Duration{isPositive: !scanner.isNegative}
 
-	_, isNegative := matches[negativePatternKey]
-	out.isPositive = !isNegative
+	for {
+		component, ok, err := scanner.next()
+		if err != nil {
+			return Duration{}, err
+		}
+		if !ok {
+			break
+		}
 
-	if yearStr, ok := matches[yearsPatternKey]; ok {
-		out.years = mustStringToFloat64(yearStr)
-	}
-	if monthStr, ok := matches[monthsPatternKey]; ok {
-		out.months = mustStringToFloat64(monthStr)
-	}
-	if weekStr, ok := matches[weeksPatternKey]; ok {
-		out.weeks = mustStringToFloat64(weekStr)
-	}
-	if dayStr, ok := matches[daysPatternKey]; ok {
-		out.days = mustStringToFloat64(dayStr)
-	}
-	if hourStr, ok := matches[hoursPatternKey]; ok {
-		out.hours = mustStringToFloat64(hourStr)
-	}
-	if minuteStr, ok := matches[minutesPatternKey]; ok {
-		out.minutes = mustStringToFloat64(minuteStr)
-	}
-	if secondStr, ok := matches[secondsPatternKey]; ok {
-		out.seconds = mustStringToFloat64(secondStr)
+		if component.isValueOverflow {
+			return Duration{}, newParseError(iso8601DurationStr, component.valueOffset, ReasonOverflow)
+		}
+
+		*out.unitValue(component.unit) = decimalFromComponent(
+			component.value, component.fraction, component.fractionDigits,
+		)
 	}
 
 	return out, nil
 }
 
-func mustStringToFloat64(in string) float64 {
-	// ignore error, as the regex ensures that the string is a valid number
-	out, _ := strconv.ParseFloat(in, 64)
-
-	return out
-}
-
-func findStringCaptureGroupMatches(
-	regex *regexp.Regexp,
-	durationStr string,
-) (map[string]string, error) {
-	captureGroups := regex.SubexpNames()
-	matches := regex.FindStringSubmatch(durationStr)
-
-	if matches == nil {
-		return nil, fmt.Errorf("could match duration string %q with regex", durationStr)
-	}
-
-	result := make(map[string]string, len(captureGroups))
-	for i, name := range captureGroups {
-		if i != 0 && name != "" && matches[i] != "" {
-			result[name] = matches[i]
-		}
-	}
-
-	return result, nil
+// unitValue returns a pointer to the field of d holding the given unit.
+func (d *Duration) unitValue(unit durationUnit) *decimal {
+	switch unit {
+	case yearUnit:
+		return &d.years
+	case monthUnit:
+		return &d.months
+	case weekUnit:
+		return &d.weeks
+	case dayUnit:
+		return &d.days
+	case hourUnit:
+		return &d.hours
+	case minuteUnit:
+		return &d.minutes
+	case secondUnit:
+		return &d.seconds
+	}
+
+	return nil
 }
 
 // DurationFromTimeDuration converts a standard Go time.Duration to an ISO 8601 Duration.
 func DurationFromTimeDuration(in time.Duration) Duration {
-	durVal := in.Abs()
+	// Abs() does not work for math.MinInt64, thus the absolute value is calculated unsigned
+	durVal := uint64(in)
+	if in < 0 {
+		durVal = -durVal
+	}
 
-	hours := float64(durVal / time.Hour)
-	durVal = durVal % time.Hour
+	hours := durVal / uint64(time.Hour)
+	durVal = durVal % uint64(time.Hour)
 
-	minutes := float64(durVal / time.Minute)
-	durVal = durVal % time.Minute
+	minutes := durVal / uint64(time.Minute)
+	durVal = durVal % uint64(time.Minute)
 
-	seconds := durVal.Seconds()
+	seconds := durVal / uint64(time.Second)
+	durVal = durVal % uint64(time.Second)
 
 	return Duration{
 		isPositive: in >= 0,
-		hours:      hours,
-		minutes:    minutes,
-		seconds:    seconds,
+		hours:      decimal{whole: hours},
+		minutes:    decimal{whole: minutes},
+		seconds:    decimal{whole: seconds, fraction: uint32(durVal)},
 	}
 }
 
@@ -159,31 +144,31 @@ func (d Duration) IsPositive() bool {
 }
 
 func (d Duration) Years() float64 {
-	return d.years
+	return d.years.float64()
 }
 
 func (d Duration) Months() float64 {
-	return d.months
+	return d.months.float64()
 }
 
 func (d Duration) Weeks() float64 {
-	return d.weeks
+	return d.weeks.float64()
 }
 
 func (d Duration) Days() float64 {
-	return d.days
+	return d.days.float64()
 }
 
 func (d Duration) Hours() float64 {
-	return d.hours
+	return d.hours.float64()
 }
 
 func (d Duration) Minutes() float64 {
-	return d.minutes
+	return d.minutes.float64()
 }
 
 func (d Duration) Seconds() float64 {
-	return d.seconds
+	return d.seconds.float64()
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -191,163 +176,578 @@ func (d Duration) Seconds() float64 {
 
 // IsZero returns true if the Duration has a 'duration length' of zero.
 func (d Duration) IsZero() bool {
-	return d.years == 0 &&
-		d.months == 0 &&
-		d.weeks == 0 &&
-		d.days == 0 &&
-		d.hours == 0 &&
-		d.minutes == 0 &&
-		d.seconds == 0
+	return d.years.isZero() &&
+		d.months.isZero() &&
+		d.weeks.isZero() &&
+		d.days.isZero() &&
+		d.hours.isZero() &&
+		d.minutes.isZero() &&
+		d.seconds.isZero()
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
-// Go std time stuff ///////////////////////////////////////////////////////////////////////////////////////////////////
+// Comparison //////////////////////////////////////////////////////////////////////////////////////////////////////////
 
-// AddToTime adds the duration to a given time.Time value.
-func (d Duration) AddToTime(stdTime time.Time) (time.Time, error) {
-	if d.IsZero() {
-		return stdTime, nil
+// StrictEqual reports whether both durations have exactly the same sign and components,
+// e.g. "PT1H" and "PT60M" are not strictly equal. Zero durations are equal regardless of their sign.
+func (d Duration) StrictEqual(other Duration) bool {
+	if d.IsZero() || other.IsZero() {
+		return d.IsZero() && other.IsZero()
 	}
 
-	multiplier := 1
-	if !d.isPositive {
-		multiplier = -1
-	}
+	return d == other
+}
 
-	yearAdd, err := float64ToInt(d.years)
+// Compare compares both durations by adding them to the reference time ref using AddToTime.
+// It returns -1 if d is shorter than other, 0 if both are equally long and +1 if d is longer than other.
+// E.g. "P1M" is longer than "P30D" for a reference time in January, but shorter in February.
+func (d Duration) Compare(other Duration, ref time.Time) (int, error) {
+	dTime, err := d.AddToTime(ref)
 	if err != nil {
-		return time.Time{}, errors.New("could not convert year to int")
+		return 0, err
 	}
-	monthAdd, err := float64ToInt(d.months)
+	otherTime, err := other.AddToTime(ref)
 	if err != nil {
-		return time.Time{}, errors.New("could not convert month to int")
+		return 0, err
 	}
 
-	weeks, decimalWeeks := math.Modf(d.weeks)
-	weekAdd, err := float64ToInt(weeks)
-	if err != nil {
-		return time.Time{}, errors.New("could not convert week to int")
+	return dTime.Compare(otherTime), nil
+}
+
+// CompareUnambiguous compares both durations without a reference time.
+// It returns -1 if d is shorter than other, 0 if both are equally long and +1 if d is longer than other.
+//
+// As months have a variable length of 28 to 31 days, ErrAmbiguousComparison is returned if the result depends on
+// the reference time, e.g. for "P1M" and "P30D". The bounds take into account that consecutive months are added,
+// e.g. "P2M" lasts at least 59 days and "P1Y" 365 or 366 days. Years are treated as 12 months, days as 24 hours and
+// weeks as 7 days, which corresponds to adding the durations in a time zone without daylight saving time, like UTC.
+func (d Duration) CompareUnambiguous(other Duration) (int, error) {
+	monthDiff := new(big.Int).Sub(d.nanoMonths(), other.nanoMonths())
+	exactDiff := new(big.Int).Sub(d.exactNanoseconds(), other.exactNanoseconds())
+
+	if monthDiff.Sign() == 0 {
+		return exactDiff.Sign(), nil
 	}
 
-	days, decimalDays := math.Modf(d.days + 7*decimalWeeks)
-	dayAdd, err := float64ToInt(days)
-	if err != nil {
-		return time.Time{}, errors.New("could not convert day + remaining week to int")
+	// the difference of the months is a run of consecutive months, which lies between the shortest and longest run
+	shortestSpan, longestSpan := monthSpanBounds(new(big.Int).Abs(monthDiff))
+	if monthDiff.Sign() < 0 {
+		shortestSpan, longestSpan = longestSpan.Neg(longestSpan), shortestSpan.Neg(shortestSpan)
 	}
 
-	hours, decimalHours := math.Modf(d.hours + 24*decimalDays)
-	hourAdd, err := float64ToInt(hours)
-	if err != nil {
-		return time.Time{}, errors.New("could not convert hour + remaining day to int")
+	minDiff := shortestSpan.Add(shortestSpan, exactDiff)
+	maxDiff := longestSpan.Add(longestSpan, exactDiff)
+
+	if minDiff.Sign() == maxDiff.Sign() {
+		return minDiff.Sign(), nil
 	}
 
-	minutes, decimalMinutes := math.Modf(d.minutes + 60*decimalHours)
-	minuteAdd, err := float64ToInt(minutes)
-	if err != nil {
-		return time.Time{}, errors.New("could not convert minute + remaining hour to int")
+	return 0, ErrAmbiguousComparison
+}
+
+// shortestMonthRuns and longestMonthRuns hold the number of days of the shortest and longest run of the given
+// number of consecutive months, e.g. two consecutive months last 59 to 62 days.
+var (
+	shortestMonthRuns = [12]int64{0, 28, 59, 89, 120, 150, 181, 212, 242, 273, 303, 334}
+	longestMonthRuns  = [12]int64{0, 31, 62, 92, 123, 153, 184, 215, 245, 276, 306, 337}
+)
+
+// monthSpanBounds returns the shortest and longest span in nanoseconds of the non-negative number of consecutive
+// months nanoMonths, given in units of 1e-9. Whole years last 365 or 366 days, the remaining months are bounded
+// by the runs of consecutive months and a fraction of a month by 28 and 31 days.
+func monthSpanBounds(nanoMonths *big.Int) (shortest, longest *big.Int) {
+	months, fraction := new(big.Int).QuoRem(nanoMonths, big.NewInt(decimalFractionUnit), new(big.Int))
+	years, remainingMonths := new(big.Int).QuoRem(months, big.NewInt(12), new(big.Int))
+
+	bound := func(yearDays, runDays, fractionDays int64) *big.Int {
+		days := new(big.Int).Mul(years, big.NewInt(yearDays))
+		days.Add(days, big.NewInt(runDays))
+
+		out := days.Mul(days, big.NewInt(int64(TimeDay)))
+		// the fraction in units of 1e-9 multiplied by the length of a day in seconds results in nanoseconds
+		fractionNanos := new(big.Int).Mul(fraction, big.NewInt(fractionDays*int64(TimeDay/time.Second)))
+
+		return out.Add(out, fractionNanos)
 	}
 
-	seconds, nanoSeconds := math.Modf(d.seconds + 60*decimalMinutes)
-	secondAdd, err := float64ToInt(seconds)
-	if err != nil {
-		return time.Time{}, errors.New("could not convert second + remaining minute to int")
+	run := remainingMonths.Int64()
+
+	return bound(365, shortestMonthRuns[run], 28), bound(366, longestMonthRuns[run], 31)
+}
+
+// nanoMonths returns the signed number of months of d, including its years, in units of 1e-9.
+func (d Duration) nanoMonths() *big.Int {
+	out := d.years.nanoUnits()
+	out.Mul(out, big.NewInt(12))
+	out.Add(out, d.months.nanoUnits())
+
+	if !d.isPositive {
+		out.Neg(out)
 	}
 
-	nanoSecondAdd, err := float64ToInt(nanoSeconds * float64(time.Second))
-	if err != nil {
-		return time.Time{}, errors.New("could not convert nanosecond to int")
+	return out
+}
+
+// exactNanoseconds returns the signed number of nanoseconds of all units of d with a fixed length,
+// i.e. all units except years and months.
+func (d Duration) exactNanoseconds() *big.Int {
+	out := new(big.Int)
+
+	units := [...]struct {
+		value   decimal
+		seconds int64
+	}{
+		{value: d.weeks, seconds: int64(TimeWeek / time.Second)},
+		{value: d.days, seconds: int64(TimeDay / time.Second)},
+		{value: d.hours, seconds: int64(time.Hour / time.Second)},
+		{value: d.minutes, seconds: int64(time.Minute / time.Second)},
+		{value: d.seconds, seconds: 1},
+	}
+	for _, unit := range units {
+		// a value in units of 1e-9 multiplied by its length in seconds results in nanoseconds
+		value := unit.value.nanoUnits()
+		out.Add(out, value.Mul(value, big.NewInt(unit.seconds)))
+	}
+
+	if !d.isPositive {
+		out.Neg(out)
+	}
+
+	return out
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// Arithmetic //////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Neg returns the Duration with the opposite sign. The zero Duration is always positive.
+func (d Duration) Neg() Duration {
+	d.isPositive = !d.isPositive || d.IsZero()
+
+	return d
+}
+
+// Abs returns the positive version of the Duration.
+func (d Duration) Abs() Duration {
+	d.isPositive = true
+
+	return d
+}
+
+// Add adds the other Duration component-wise, e.g. "P1M" plus "P2DT3H" results in "P1M2DT3H".
+//
+// Components are added as signed values, e.g. "P2D" plus "-P1D" results in "P1D".
+// As a Duration only has a single sign, ErrMixedSigns is returned if the resulting components
+// would have different signs, e.g. for "P1M" plus "-P1D".
+func (d Duration) Add(other Duration) (Duration, error) {
+	out := Duration{isPositive: true}
+	hasPositive := false
+	hasNegative := false
+
+	for unit := yearUnit; unit <= secondUnit; unit++ {
+		isPositive, value, ok := addSigned(
+			d.isPositive, *d.unitValue(unit),
+			other.isPositive, *other.unitValue(unit),
+		)
+		if !ok {
+			return Duration{}, ErrOverflow
+		}
+
+		if !value.isZero() {
+			hasPositive = hasPositive || isPositive
+			hasNegative = hasNegative || !isPositive
+		}
+		*out.unitValue(unit) = value
+	}
+
+	if hasPositive && hasNegative {
+		return Duration{}, ErrMixedSigns
+	}
+	out.isPositive = !hasNegative
+
+	return out, nil
+}
+
+// Sub subtracts the other Duration component-wise. It follows the same rules as Add.
+func (d Duration) Sub(other Duration) (Duration, error) {
+	return d.Add(other.Neg())
+}
+
+// Mul multiplies every component of the Duration with factor.
+// It returns ErrOverflow if a component exceeds the supported range.
+func (d Duration) Mul(factor int) (Duration, error) {
+	absFactor := uint64(factor)
+	if factor < 0 {
+		absFactor = -absFactor
+	}
+
+	out := Duration{isPositive: d.isPositive == (factor >= 0)}
+	for unit := yearUnit; unit <= secondUnit; unit++ {
+		value, ok := d.unitValue(unit).mul(absFactor)
+		if !ok {
+			return Duration{}, ErrOverflow
+		}
+
+		*out.unitValue(unit) = value
+	}
+
+	// the zero Duration is always positive
+	out.isPositive = out.isPositive || out.IsZero()
+
+	return out, nil
+}
+
+// Scale multiplies every component of the Duration with factor and rounds the results to 9 fractional digits.
+// Unlike Mul, the calculation is done with floating point numbers and thus is not exact.
+// It returns ErrOverflow if a component exceeds the supported range or factor is not a finite number.
+func (d Duration) Scale(factor float64) (Duration, error) {
+	if math.IsNaN(factor) || math.IsInf(factor, 0) {
+		return Duration{}, ErrOverflow
+	}
+
+	out := Duration{isPositive: d.isPositive == (factor >= 0)}
+	for unit := yearUnit; unit <= secondUnit; unit++ {
+		value, ok := decimalFromFloat64Rounded(d.unitValue(unit).float64() * math.Abs(factor))
+		if !ok {
+			return Duration{}, ErrOverflow
+		}
+
+		*out.unitValue(unit) = value
+	}
+
+	// the zero Duration is always positive
+	out.isPositive = out.isPositive || out.IsZero()
+
+	return out, nil
+}
+
+// addSigned adds the signed decimals a and b and returns the sign and absolute value of the result.
+// It reports false if the result exceeds the supported range.
+func addSigned(aIsPositive bool, a decimal, bIsPositive bool, b decimal) (bool, decimal, bool) {
+	if aIsPositive == bIsPositive {
+		sum, ok := a.add(b)
+
+		return aIsPositive, sum, ok
+	}
+
+	if a.cmp(b) >= 0 {
+		return aIsPositive, a.sub(b), true
+	}
+
+	return bIsPositive, b.sub(a), true
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// Normalization ///////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// NormalizeOptions configures Duration.Normalize.
+// The zero value only carries seconds into minutes and minutes into hours.
+type NormalizeOptions struct {
+	// CarryHoursToDays carries every 24 hours into a day, assuming that every day is 24 hours long.
+	CarryHoursToDays bool
+	// CarryDaysToWeeks carries every 7 days into a week.
+	CarryDaysToWeeks bool
+	// CarryMonthsToYears carries every 12 months into a year.
+	CarryMonthsToYears bool
+
+	// SpreadFractions spreads the decimal fraction of every unit larger than seconds into the next smaller unit,
+	// e.g. "P1.5D" results in "P1DT12H". Fractions of months are spread into days using DaysPerMonth.
+	SpreadFractions bool
+	// DaysPerMonth is the number of days of a month used to spread fractions of months.
+	// Defaults to 30 (like TimeMonth) if zero.
+	DaysPerMonth uint64
+}
+
+// Normalize returns the Duration in a canonical carry-over form, so that equal durations have the same
+// String representation, e.g. "PT90M" results in "PT1H30M" and "PT3600S" in "PT1H".
+//
+// Seconds are always carried into minutes and minutes into hours.
+// Carrying the other units and spreading decimal fractions into smaller units can be enabled with opts.
+// It returns ErrOverflow if a component exceeds the supported range.
+func (d Duration) Normalize(opts NormalizeOptions) (Duration, error) {
+	out := d
+
+	if opts.SpreadFractions {
+		daysPerMonth := opts.DaysPerMonth
+		if daysPerMonth == 0 {
+			daysPerMonth = uint64(TimeMonth / TimeDay)
+		}
+
+		spreads := [...]struct {
+			from   durationUnit
+			to     durationUnit
+			factor uint64
+		}{
+			{from: yearUnit, to: monthUnit, factor: 12},
+			{from: monthUnit, to: dayUnit, factor: daysPerMonth},
+			{from: weekUnit, to: dayUnit, factor: 7},
+			{from: dayUnit, to: hourUnit, factor: 24},
+			{from: hourUnit, to: minuteUnit, factor: 60},
+			{from: minuteUnit, to: secondUnit, factor: 60},
+		}
+		for _, spread := range spreads {
+			from := out.unitValue(spread.from)
+			to := out.unitValue(spread.to)
+
+			spreadValue, ok := decimal{fraction: from.fraction}.mul(spread.factor)
+			if !ok {
+				return Duration{}, ErrOverflow
+			}
+			if *to, ok = to.add(spreadValue); !ok {
+				return Duration{}, ErrOverflow
+			}
+			from.fraction = 0
+		}
 	}
 
-	out := stdTime.AddDate(
-		multiplier*yearAdd,
-		multiplier*monthAdd,
-		multiplier*(weekAdd*7+dayAdd),
-	).Add(
-		time.Hour*time.Duration(hourAdd) +
-			time.Minute*time.Duration(minuteAdd) +
-			time.Second*time.Duration(secondAdd) +
-			time.Nanosecond*time.Duration(nanoSecondAdd),
-	)
+	carries := [...]struct {
+		from    durationUnit
+		to      durationUnit
+		factor  uint64
+		enabled bool
+	}{
+		{from: secondUnit, to: minuteUnit, factor: 60, enabled: true},
+		{from: minuteUnit, to: hourUnit, factor: 60, enabled: true},
+		{from: hourUnit, to: dayUnit, factor: 24, enabled: opts.CarryHoursToDays},
+		{from: dayUnit, to: weekUnit, factor: 7, enabled: opts.CarryDaysToWeeks},
+		{from: monthUnit, to: yearUnit, factor: 12, enabled: opts.CarryMonthsToYears},
+	}
+	for _, carry := range carries {
+		if !carry.enabled {
+			continue
+		}
+
+		from := out.unitValue(carry.from)
+		to := out.unitValue(carry.to)
+
+		var ok bool
+		if *to, ok = to.add(decimal{whole: from.whole / carry.factor}); !ok {
+			return Duration{}, ErrOverflow
+		}
+		from.whole %= carry.factor
+	}
 
 	return out, nil
 }
 
-func float64ToInt(in float64) (int, error) {
-	intVal, fracVal := math.Modf(in)
-	if fracVal != 0.0 {
-		return 0, errors.New("float containing decimals not supported")
+////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
+// Go std time stuff ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// AddToTime adds the duration to a given time.Time value.
+//
+// Years, months, weeks and whole days are added to the calendar date using time.Time.AddDate, while hours,
+// minutes, seconds and fractions of days are added as absolute time using time.Time.Add.
+// Across a daylight saving time change, "P1D" therefore keeps the local time of day while "PT24H" does not.
+// Fractional years and months are not supported, use AddToTimeWithOptions to add those
+// or to configure the handling of month ends and daylight saving time.
+func (d Duration) AddToTime(stdTime time.Time) (time.Time, error) {
+	return d.applyToTime(stdTime, d.isPositive, AddOptions{})
+}
+
+// AddToTimeWithOptions works like AddToTime, but allows configuring how the duration is added with opts.
+func (d Duration) AddToTimeWithOptions(stdTime time.Time, opts AddOptions) (time.Time, error) {
+	return d.applyToTime(stdTime, d.isPositive, opts)
+}
+
+// SubtractFromTime subtracts the duration from a given time.Time value, e.g. "P1M" results in one month earlier.
+// It follows the same rules as AddToTime.
+func (d Duration) SubtractFromTime(stdTime time.Time) (time.Time, error) {
+	return d.applyToTime(stdTime, !d.isPositive, AddOptions{})
+}
+
+// Resolve returns the end of the time span that starts at start and has the length of the duration.
+// It is the counterpart of Between, so that Between(start, end) resolves to end for any start.
+func (d Duration) Resolve(start time.Time) (end time.Time, err error) {
+	return d.applyToTime(start, d.isPositive, AddOptions{})
+}
+
+// Between returns the calendar-aware Duration separating start and end, consisting of years, months, days,
+// hours, minutes and seconds, e.g. "P1M" for the 15th of January and the 15th of February.
+// The result is negative if end is before start. Resolving the result from start always results in end.
+func Between(start, end time.Time) Duration {
+	sign := 1
+	if end.Before(start) {
+		sign = -1
+	}
+
+	// isPast reports whether t lies beyond end, as seen from start
+	isPast := func(t time.Time) bool {
+		return t.Compare(end) == sign
+	}
+	// addDate adds the signed months and days to start with the same arithmetic as Resolve
+	addDate := func(months, days int) time.Time {
+		out, _ := AddOptions{}.addDate(start, 0, sign*months, sign*days) // cannot fail with the default options
+
+		return out
+	}
+
+	// the number of months is estimated by the calendar dates and corrected afterward
+	months := sign * ((end.Year()-start.Year())*12 + int(end.Month()) - int(start.Month()))
+	months = max(months, 0)
+	for months > 0 && isPast(addDate(months, 0)) {
+		months--
+	}
+	for !isPast(addDate(months+1, 0)) {
+		months++
+	}
+
+	// the number of days is estimated by the elapsed time and corrected afterward
+	days := int(time.Duration(sign) * end.Sub(addDate(months, 0)) / TimeDay)
+	for days > 0 && isPast(addDate(months, days)) {
+		days--
+	}
+	for !isPast(addDate(months, days+1)) {
+		days++
+	}
+
+	remaining := end.Sub(addDate(months, days))
+
+	out := DurationFromTimeDuration(remaining)
+	out.isPositive = sign > 0
+	out.years = decimal{whole: uint64(months / 12)}
+	out.months = decimal{whole: uint64(months % 12)}
+	out.days = decimal{whole: uint64(days)}
+
+	return out
+}
+
+// ToTimeDuration converts the Duration into a time.Duration.
+// It returns ErrDateUnit if the Duration contains years, months, weeks or days, as they do not have a fixed length,
+// and ErrOverflow if the result exceeds the time.Duration range.
+// Use ToTimeDurationAt or ToTimeDurationNominal to convert those.
+func (d Duration) ToTimeDuration() (time.Duration, error) {
+	if !d.years.isZero() || !d.months.isZero() || !d.weeks.isZero() || !d.days.isZero() {
+		return 0, ErrDateUnit
 	}
 
-	out := int(intVal)
+	return d.sumTimeDuration(unitDurations, false)
+}
+
+// ToTimeDurationAt converts the Duration into a time.Duration by adding it to the reference time ref using AddToTime,
+// i.e. it returns the exact time elapsed between ref and the end of the Duration.
+// It returns ErrOverflow if the result exceeds the time.Duration range.
+func (d Duration) ToTimeDurationAt(ref time.Time) (time.Duration, error) {
+	end, err := d.AddToTime(ref)
+	if err != nil {
+		return 0, err
+	}
 
-	if (out < 0) != (intVal < 0) {
-		return 0, errors.New("float int val exceeds integer capacity")
+	out := end.Sub(ref)
+	if !ref.Add(out).Equal(end) {
+		// time.Time.Sub saturates at the bounds of time.Duration
+		return 0, ErrOverflow
 	}
 
 	return out, nil
 }
 
+// ToTimeDurationNominal converts the Duration into a time.Duration using the nominal unit lengths and policies
+// of the Parser p, just like p.ParseToDuration(d.String()) would.
+// It returns ErrCalendarUnit if the Duration contains years or months, but p does not approximate them,
+// and ErrOverflow if the result exceeds the time.Duration range and p does not clamp overflows.
+func (d Duration) ToTimeDurationNominal(p Parser) (time.Duration, error) {
+	if p.CalendarUnits != CalendarUnitsApproximate && (!d.years.isZero() || !d.months.isZero()) {
+		return 0, ErrCalendarUnit
+	}
+
+	return d.sumTimeDuration(p.unitDurations(), p.ClampOverflow)
+}
+
+// sumTimeDuration returns the sum of all components of d, using the given time.Duration of each durationUnit.
+func (d Duration) sumTimeDuration(durations [len(unitDurations)]time.Duration, clampOverflow bool) (time.Duration, error) {
+	sum := newDurationSum(!d.isPositive)
+
+	for unit := yearUnit; unit <= secondUnit; unit++ {
+		if !sum.addDecimal(*d.unitValue(unit), durations[unit]) {
+			if !clampOverflow {
+				return 0, ErrOverflow
+			}
+
+			sum.isOverflow = true
+			break
+		}
+	}
+
+	return sum.duration(), nil
+}
+
 ////////////////////////////////////////////////////////////////////////////////////////////////////////////////////////
 // Formatting //////////////////////////////////////////////////////////////////////////////////////////////////////////
 
+// DecimalSeparator is the character separating the integer part of a value from its decimal fraction.
+type DecimalSeparator byte
+
+// decimal separators allowed by ISO 8601
+const (
+	DecimalPoint DecimalSeparator = decimalPointDesignator
+	DecimalComma DecimalSeparator = decimalCommaDesignator
+)
+
 // String returns a string representing the Duration in the ISO 8601 format.
 // Leading zero units are omitted.
 // The result counts as a valid ISO8601 duration.
 //
 // It supports negative durations, as detailed in the extension ISO 8601-2.
+// Like the result of Neg, a zero duration never has a sign, e.g. for "-PT0S" or the zero value of Duration.
 func (d Duration) String() string {
+	return d.StringWithSeparator(DecimalPoint)
+}
+
+// StringWithSeparator works like String, but uses the given DecimalSeparator for decimal fractions.
+// Any value other than DecimalComma results in DecimalPoint being used.
+func (d Duration) StringWithSeparator(separator DecimalSeparator) string {
 	hasDate := false
 	hasTime := false
 
-	out := "P"
-	if !d.isPositive {
-		out = "-P"
+	out := make([]byte, 0, 32)
+	if !d.isPositive && !d.IsZero() {
+		out = append(out, '-')
 	}
+	out = append(out, startDesignator)
 
-	if d.years != 0 {
-		appendDurationPart(&out, d.years, yearDesignator)
+	if !d.years.isZero() {
+		appendDurationPart(&out, d.years, yearDesignator, separator)
 		hasDate = true
 	}
-	if d.months != 0 {
-		appendDurationPart(&out, d.months, monthDesignator)
+	if !d.months.isZero() {
+		appendDurationPart(&out, d.months, monthDesignator, separator)
 		hasDate = true
 	}
-	if d.weeks != 0 {
-		appendDurationPart(&out, d.weeks, weekDesignator)
+	if !d.weeks.isZero() {
+		appendDurationPart(&out, d.weeks, weekDesignator, separator)
 		hasDate = true
 	}
-	if d.days != 0 {
-		appendDurationPart(&out, d.days, dayDesignator)
+	if !d.days.isZero() {
+		appendDurationPart(&out, d.days, dayDesignator, separator)
 		hasDate = true
 	}
 
-	if d.hours != 0 {
-		out += "T"
-		appendDurationPart(&out, d.hours, hourDesignator)
+	if !d.hours.isZero() {
+		out = append(out, timeSwitchDesignator)
+		appendDurationPart(&out, d.hours, hourDesignator, separator)
 		hasTime = true
 	}
-	if d.minutes != 0 {
+	if !d.minutes.isZero() {
 		if !hasTime {
-			out += "T"
+			out = append(out, timeSwitchDesignator)
 		}
-		appendDurationPart(&out, d.minutes, minuteDesignator)
+		appendDurationPart(&out, d.minutes, minuteDesignator, separator)
 		hasTime = true
 	}
-	if d.seconds != 0 {
+	if !d.seconds.isZero() {
 		if !hasTime {
-			out += "T"
+			out = append(out, timeSwitchDesignator)
 		}
-		appendDurationPart(&out, d.seconds, secondDesignator)
+		appendDurationPart(&out, d.seconds, secondDesignator, separator)
 		hasTime = true
 	}
 
 	if !hasDate && !hasTime {
-		out += "T0S"
+		out = append(out, "T0S"...)
 	}
 
-	return out
+	return string(out)
 }
 
-func appendDurationPart(buf *string, value float64, suffix rune) {
-	*buf += strconv.FormatFloat(value, 'f', -1, 64) + string(suffix)
+func appendDurationPart(buf *[]byte, value decimal, suffix byte, separator DecimalSeparator) {
+	*buf = append(value.appendTo(*buf, separator), suffix)
 }
@@ -4,6 +4,7 @@ import (
 	"github.com/Achsion/iso8601/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"math"
 	"testing"
 	"time"
 )
@@ -18,6 +19,29 @@ func newDuration(
 	return out
 }
 
+func TestNewDuration_Rounding(t *testing.T) {
+	testCases := []struct {
+		name     string
+		seconds  float64
+		expected string
+	}{
+		{name: "shortest representation", seconds: 0.1, expected: "PT0.1S"},
+		{name: "nine fractional digits", seconds: 0.000000001, expected: "PT0.000000001S"},
+		{name: "repeating fraction", seconds: 1.0 / 3, expected: "PT0.333333333S"},
+		{name: "rounded up", seconds: 2.0 / 3, expected: "PT0.666666667S"},
+		{name: "rounded to zero", seconds: 0.0000000001, expected: "PT0S"},
+		{name: "rounded to whole", seconds: 1.9999999999, expected: "PT2S"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := iso8601.NewDuration(true, 0, 0, 0, 0, 0, 0, tc.seconds)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual.String())
+		})
+	}
+}
+
 func TestNewDuration_Error(t *testing.T) {
 	t.Run("negative year", func(t *testing.T) {
 		_, err := iso8601.NewDuration(true, -1, 0, 0, 0, 0, 0, 0)
@@ -47,6 +71,18 @@ func TestNewDuration_Error(t *testing.T) {
 		_, err := iso8601.NewDuration(true, 0, 0, 0, 0, 0, 0, -1)
 		assert.Error(t, err)
 	})
+	t.Run("not a number", func(t *testing.T) {
+		_, err := iso8601.NewDuration(true, 0, 0, 0, 0, 0, 0, math.NaN())
+		assert.Error(t, err)
+	})
+	t.Run("infinite", func(t *testing.T) {
+		_, err := iso8601.NewDuration(true, 0, 0, 0, 0, 0, math.Inf(1), 0)
+		assert.Error(t, err)
+	})
+	t.Run("too large", func(t *testing.T) {
+		_, err := iso8601.NewDuration(true, 1e20, 0, 0, 0, 0, 0, 0)
+		assert.Error(t, err)
+	})
 }
 
 func TestDurationFromString_Error(t *testing.T) {
@@ -98,6 +134,58 @@ func TestDurationFromString_Error(t *testing.T) {
 			name:   "double designator",
 			isoStr: "P1Y2M3DT4H3H5M6S",
 		},
+		{
+			name:   "empty string",
+			isoStr: "",
+		},
+		{
+			name:   "only negative sign",
+			isoStr: "-",
+		},
+		{
+			name:   "double negative sign",
+			isoStr: "--P1D",
+		},
+		{
+			name:   "double time designator",
+			isoStr: "PT1HT1M",
+		},
+		{
+			name:   "designator without value",
+			isoStr: "PY",
+		},
+		{
+			name:   "date designator in time section",
+			isoStr: "PT1D",
+		},
+		{
+			name:   "multiple decimal points",
+			isoStr: "P1..2Y",
+		},
+		{
+			name:   "only decimal point",
+			isoStr: "P.Y",
+		},
+		{
+			name:   "multiple fractions",
+			isoStr: "P1.2.3D",
+		},
+		{
+			name:   "fraction on a component that is not the lowest-order one",
+			isoStr: "P1.5Y2M",
+		},
+		{
+			name:   "empty time section",
+			isoStr: "PT",
+		},
+		{
+			name:   "empty time section after date",
+			isoStr: "P1DT",
+		},
+		{
+			name:   "no components",
+			isoStr: "P",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -210,8 +298,16 @@ func TestDurationFromString_Success(t *testing.T) {
 			expected: newDuration(t, true, 1, 2, 3, 4, 5, 6, 7),
 		},
 		{
-			isoStr:   "PT1.23456789123S",
-			expected: newDuration(t, true, 0, 0, 0, 0, 0, 0, 1.23456789123),
+			isoStr:   "PT1.23456789123S", // Too many decimal points, the last '23' will be cut/removed/ignored.
+			expected: newDuration(t, true, 0, 0, 0, 0, 0, 0, 1.234567891),
+		},
+		{
+			isoStr:   "PT1,5S", // decimal comma, as preferred by ISO 8601
+			expected: newDuration(t, true, 0, 0, 0, 0, 0, 0, 1.5),
+		},
+		{
+			isoStr:   "P1,25Y",
+			expected: newDuration(t, true, 1.25, 0, 0, 0, 0, 0, 0),
 		},
 	}
 
@@ -225,6 +321,65 @@ func TestDurationFromString_Success(t *testing.T) {
 	}
 }
 
+func TestDurationFromString_RoundTrip(t *testing.T) {
+	testCases := []string{
+		"PT0.1S",
+		"PT0.000000001S",
+		"PT0.999999999S",
+		"P0.1Y",
+		"P1Y0.2M",
+		"P0.3W",
+		"P0.7D",
+		"PT0.1H",
+		"PT0.3M",
+		"P1Y2M3W4DT5H6M7.89S",
+		"-P1Y2M3W4DT5H6M7.123456789S",
+		"P18446744073709551615Y",
+		"PT18446744073709551615.999999999S",
+	}
+
+	for _, isoStr := range testCases {
+		t.Run(isoStr, func(t *testing.T) {
+			actual, err := iso8601.DurationFromString(isoStr)
+			require.NoError(t, err)
+
+			assert.Equal(t, isoStr, actual.String())
+		})
+	}
+}
+
+func TestDuration_Getter(t *testing.T) {
+	dur := newDuration(t, false, 0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7)
+
+	assert.False(t, dur.IsPositive())
+	assert.Equal(t, 0.1, dur.Years())
+	assert.Equal(t, 0.2, dur.Months())
+	assert.Equal(t, 0.3, dur.Weeks())
+	assert.Equal(t, 0.4, dur.Days())
+	assert.Equal(t, 0.5, dur.Hours())
+	assert.Equal(t, 0.6, dur.Minutes())
+	assert.Equal(t, 0.7, dur.Seconds())
+}
+
+func TestDurationFromString_NoAllocations(t *testing.T) {
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = iso8601.DurationFromString("-P12Y32M3W153DT7H15M6.7023S")
+	})
+	assert.Zero(t, allocs)
+
+	allocs = testing.AllocsPerRun(100, func() {
+		_, _ = iso8601.DurationFromString("-P12Y32M3W153DT7H15M6,7023S")
+	})
+	assert.Zero(t, allocs)
+}
+
+func TestNewDuration_NoAllocations(t *testing.T) {
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = iso8601.NewDuration(true, 1, 2.5, 0, 4, 0.1, 1.0/3, 123456.789)
+	})
+	assert.Zero(t, allocs)
+}
+
 func TestDurationFromTimeDuration(t *testing.T) {
 	testCases := []struct {
 		name     string
@@ -256,6 +411,11 @@ func TestDurationFromTimeDuration(t *testing.T) {
 			in:       -48*time.Hour - 10*time.Minute - 7*time.Second,
 			expected: newDuration(t, false, 0, 0, 0, 0, 48, 10, 7),
 		},
+		{
+			name:     "min duration",
+			in:       math.MinInt64,
+			expected: newDuration(t, false, 0, 0, 0, 0, 2562047, 47, 16.854775808),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -285,8 +445,12 @@ func TestDuration_AddToTime_Error(t *testing.T) {
 			dur:  newDuration(t, true, 0, 1.1, 0, 0, 0, 0, 0),
 		},
 		{
-			name: "too many second decimal values",
-			dur:  newDuration(t, true, 0, 0, 0, 0, 0, 0, 0.0000000001),
+			name: "week too large",
+			dur:  newDuration(t, true, 0, 0, 9223372036854775807, 0, 0, 0, 0),
+		},
+		{
+			name: "hours exceed time.Duration",
+			dur:  newDuration(t, true, 0, 0, 0, 0, 2562048, 0, 0),
 		},
 	}
 
@@ -348,6 +512,18 @@ func TestDuration_AddToTime_Success(t *testing.T) {
 			stdTime:  time.Date(2003, 3, 3, 2, 15, 15, 0, time.UTC),
 			expected: time.Date(2004, 4, 7, 20, 48, 48, 500*int(time.Millisecond), time.UTC),
 		},
+		{
+			name:     "without float noise",
+			dur:      newDuration(t, true, 0, 0, 0, 0.1, 0.1, 0.1, 0.1),
+			stdTime:  time.Date(2003, 3, 3, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2003, 3, 3, 2, 30, 6, 100*int(time.Millisecond), time.UTC),
+		},
+		{
+			name:     "negative",
+			dur:      newDuration(t, false, 1, 1, 0, 1, 3, 3, 3.5),
+			stdTime:  time.Date(2003, 3, 3, 15, 15, 15, 0, time.UTC),
+			expected: time.Date(2002, 2, 2, 12, 12, 11, 500*int(time.Millisecond), time.UTC),
+		},
 	}
 
 	for _, tc := range testCases {
@@ -360,6 +536,667 @@ func TestDuration_AddToTime_Success(t *testing.T) {
 	}
 }
 
+func TestDuration_AddToTime_AmbiguousLocalTime(t *testing.T) {
+	berlin := mustLoadLocation(t, "Europe/Berlin")
+	stdTime := time.Date(2024, 10, 26, 2, 30, 0, 0, berlin)
+
+	actual, err := mustDurationFromString(t, "P1D").AddToTime(stdTime)
+	require.NoError(t, err)
+
+	// the same as time.Time.AddDate, which results in 02:30 CET after the clocks went back
+	expected := stdTime.AddDate(0, 0, 1)
+	assert.True(t, expected.Equal(actual), "expected %s, actual %s", expected, actual)
+	_, offset := actual.Zone()
+	assert.Equal(t, 3600, offset)
+}
+
+func mustDurationFromString(t require.TestingT, isoStr string) iso8601.Duration {
+	out, err := iso8601.DurationFromString(isoStr)
+	require.NoError(t, err)
+
+	return out
+}
+
+func TestDuration_StrictEqual(t *testing.T) {
+	testCases := []struct {
+		a        string
+		b        string
+		expected bool
+	}{
+		{a: "P1M2DT3H", b: "P1M2DT3H", expected: true},
+		{a: "PT0.1S", b: "PT0.100S", expected: true},
+		{a: "PT0S", b: "-PT0S", expected: true},
+		{a: "PT0S", b: "P0D", expected: true},
+		{a: "PT1H", b: "PT60M", expected: false},
+		{a: "P1D", b: "-P1D", expected: false},
+		{a: "P1Y", b: "P12M", expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.a+"="+tc.b, func(t *testing.T) {
+			actual := mustDurationFromString(t, tc.a).StrictEqual(mustDurationFromString(t, tc.b))
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestDuration_Compare(t *testing.T) {
+	january := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	february := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		a        string
+		b        string
+		ref      time.Time
+		expected int
+	}{
+		{name: "month longer than 30 days in january", a: "P1M", b: "P30D", ref: january, expected: 1},
+		{name: "month shorter than 30 days in february", a: "P1M", b: "P30D", ref: february, expected: -1},
+		{name: "month equal to 28 days in february", a: "P1M", b: "P28D", ref: february, expected: 0},
+		{name: "hours and minutes", a: "PT1H", b: "PT60M", ref: january, expected: 0},
+		{name: "negative", a: "-P1D", b: "PT1H", ref: january, expected: -1},
+		{name: "leap year", a: "P1Y", b: "P365D", ref: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), expected: 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := mustDurationFromString(t, tc.a).Compare(mustDurationFromString(t, tc.b), tc.ref)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+
+	t.Run("error", func(t *testing.T) {
+		_, err := mustDurationFromString(t, "P1.5Y").Compare(mustDurationFromString(t, "P1D"), january)
+		assert.Error(t, err)
+	})
+}
+
+func TestDuration_CompareUnambiguous(t *testing.T) {
+	testCases := []struct {
+		a        string
+		b        string
+		expected int
+	}{
+		{a: "PT1H", b: "PT60M", expected: 0},
+		{a: "PT1H", b: "PT59M", expected: 1},
+		{a: "P1W", b: "P7D", expected: 0},
+		{a: "P1D", b: "PT24H", expected: 0},
+		{a: "P1Y", b: "P12M", expected: 0},
+		{a: "P1Y1D", b: "P12M", expected: 1},
+		{a: "P1M", b: "P32D", expected: -1},
+		{a: "P1M", b: "P27DT23H", expected: 1},
+		{a: "P2M", b: "P1M27D", expected: 1},
+		{a: "P1Y", b: "P360D", expected: 1},
+		{a: "P1Y", b: "P367D", expected: -1},
+		{a: "P2M", b: "P57D", expected: 1},
+		{a: "P2M", b: "P63D", expected: -1},
+		{a: "P14M", b: "P1Y57D", expected: 1},
+		{a: "P3M", b: "P1M58D", expected: 1},
+		{a: "-P1Y", b: "-P360D", expected: -1},
+		{a: "P1.5M", b: "P41D", expected: 1},
+		{a: "-P1M", b: "-P32D", expected: 1},
+		{a: "-P1M", b: "P1D", expected: -1},
+		{a: "PT0S", b: "-PT0S", expected: 0},
+		{a: "PT0.000000001S", b: "PT0S", expected: 1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.a+"<>"+tc.b, func(t *testing.T) {
+			actual, err := mustDurationFromString(t, tc.a).CompareUnambiguous(mustDurationFromString(t, tc.b))
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+
+	ambiguousCases := []struct {
+		a string
+		b string
+	}{
+		{a: "P1M", b: "P30D"},
+		{a: "P1M", b: "P28D"},
+		{a: "P1M", b: "P31D"},
+		{a: "P1Y", b: "P365D"},
+		{a: "-P1M", b: "-P29D"},
+		{a: "P1Y", b: "P366D"},
+		{a: "P2M", b: "P60D"},
+		{a: "P3M", b: "P1M60D"},
+		{a: "P13M", b: "P394D"},
+	}
+
+	for _, tc := range ambiguousCases {
+		t.Run(tc.a+"<>"+tc.b+" ambiguous", func(t *testing.T) {
+			_, err := mustDurationFromString(t, tc.a).CompareUnambiguous(mustDurationFromString(t, tc.b))
+			assert.ErrorIs(t, err, iso8601.ErrAmbiguousComparison)
+		})
+	}
+}
+
+func TestDuration_Neg(t *testing.T) {
+	assert.Equal(t, "-P1M2DT3H", mustDurationFromString(t, "P1M2DT3H").Neg().String())
+	assert.Equal(t, "P1M2DT3H", mustDurationFromString(t, "-P1M2DT3H").Neg().String())
+	assert.Equal(t, "PT0S", mustDurationFromString(t, "PT0S").Neg().String())
+}
+
+func TestDuration_Abs(t *testing.T) {
+	assert.Equal(t, "P1M2DT3H", mustDurationFromString(t, "P1M2DT3H").Abs().String())
+	assert.Equal(t, "P1M2DT3H", mustDurationFromString(t, "-P1M2DT3H").Abs().String())
+}
+
+func TestDuration_Add(t *testing.T) {
+	testCases := []struct {
+		a        string
+		b        string
+		expected string
+	}{
+		{a: "P1M", b: "P2DT3H", expected: "P1M2DT3H"},
+		{a: "P1Y2M3W4DT5H6M7.8S", b: "P1Y2M3W4DT5H6M7.8S", expected: "P2Y4M6W8DT10H12M15.6S"},
+		{a: "PT0.1S", b: "PT0.2S", expected: "PT0.3S"},
+		{a: "PT0.5S", b: "PT0.5S", expected: "PT1S"},
+		{a: "-P1D", b: "-PT1H", expected: "-P1DT1H"},
+		{a: "P2D", b: "-P1D", expected: "P1D"},
+		{a: "P1D", b: "-P2D", expected: "-P1D"},
+		{a: "P1DT1H", b: "-P1D", expected: "PT1H"},
+		{a: "P1D", b: "-P1D", expected: "PT0S"},
+		{a: "PT1.5S", b: "-PT0.7S", expected: "PT0.8S"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.a+"+"+tc.b, func(t *testing.T) {
+			actual, err := mustDurationFromString(t, tc.a).Add(mustDurationFromString(t, tc.b))
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual.String())
+		})
+	}
+}
+
+func TestDuration_Add_Error(t *testing.T) {
+	t.Run("mixed signs", func(t *testing.T) {
+		_, err := mustDurationFromString(t, "P1M").Add(mustDurationFromString(t, "-P1D"))
+		assert.ErrorIs(t, err, iso8601.ErrMixedSigns)
+	})
+	t.Run("overflow", func(t *testing.T) {
+		_, err := mustDurationFromString(t, "P18446744073709551615Y").Add(mustDurationFromString(t, "P1Y"))
+		assert.ErrorIs(t, err, iso8601.ErrOverflow)
+	})
+}
+
+func TestDuration_Sub(t *testing.T) {
+	testCases := []struct {
+		a        string
+		b        string
+		expected string
+	}{
+		{a: "P1M2DT3H", b: "P2DT3H", expected: "P1M"},
+		{a: "P1D", b: "P2D", expected: "-P1D"},
+		{a: "P1D", b: "-P2D", expected: "P3D"},
+		{a: "PT1S", b: "PT0.000000001S", expected: "PT0.999999999S"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.a+"-"+tc.b, func(t *testing.T) {
+			actual, err := mustDurationFromString(t, tc.a).Sub(mustDurationFromString(t, tc.b))
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual.String())
+		})
+	}
+
+	t.Run("mixed signs", func(t *testing.T) {
+		_, err := mustDurationFromString(t, "P1M").Sub(mustDurationFromString(t, "P1D"))
+		assert.ErrorIs(t, err, iso8601.ErrMixedSigns)
+	})
+}
+
+func TestDuration_Mul(t *testing.T) {
+	testCases := []struct {
+		dur      string
+		factor   int
+		expected string
+	}{
+		{dur: "P1M2DT3H", factor: 3, expected: "P3M6DT9H"},
+		{dur: "PT0.1S", factor: 3, expected: "PT0.3S"},
+		{dur: "PT0.333333333S", factor: 3, expected: "PT0.999999999S"},
+		{dur: "P1.5W", factor: 4, expected: "P6W"},
+		{dur: "P1D", factor: -2, expected: "-P2D"},
+		{dur: "-P1D", factor: -2, expected: "P2D"},
+		{dur: "-P1D", factor: 0, expected: "PT0S"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.dur, func(t *testing.T) {
+			actual, err := mustDurationFromString(t, tc.dur).Mul(tc.factor)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual.String())
+		})
+	}
+
+	t.Run("overflow", func(t *testing.T) {
+		_, err := mustDurationFromString(t, "P9223372036854775808Y").Mul(2)
+		assert.ErrorIs(t, err, iso8601.ErrOverflow)
+	})
+	t.Run("min int factor", func(t *testing.T) {
+		actual, err := mustDurationFromString(t, "PT0S").Mul(math.MinInt)
+		require.NoError(t, err)
+		assert.True(t, actual.IsZero())
+	})
+}
+
+func TestDuration_Scale(t *testing.T) {
+	testCases := []struct {
+		dur      string
+		factor   float64
+		expected string
+	}{
+		{dur: "P1M2DT3H", factor: 1.5, expected: "P1.5M3DT4.5H"},
+		{dur: "PT1S", factor: 0.1, expected: "PT0.1S"},
+		{dur: "PT1S", factor: 1.0 / 3, expected: "PT0.333333333S"},
+		{dur: "PT2S", factor: 1.0 / 3, expected: "PT0.666666667S"},
+		{dur: "P1D", factor: -0.5, expected: "-P0.5D"},
+		{dur: "P1D", factor: 0, expected: "PT0S"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.dur, func(t *testing.T) {
+			actual, err := mustDurationFromString(t, tc.dur).Scale(tc.factor)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual.String())
+		})
+	}
+
+	t.Run("not a number", func(t *testing.T) {
+		_, err := mustDurationFromString(t, "P1D").Scale(math.NaN())
+		assert.ErrorIs(t, err, iso8601.ErrOverflow)
+	})
+	t.Run("overflow", func(t *testing.T) {
+		_, err := mustDurationFromString(t, "P18446744073709551615D").Scale(2)
+		assert.ErrorIs(t, err, iso8601.ErrOverflow)
+	})
+}
+
+func TestDuration_Normalize(t *testing.T) {
+	testCases := []struct {
+		name     string
+		dur      string
+		opts     iso8601.NormalizeOptions
+		expected string
+	}{
+		{name: "minutes into hours", dur: "PT90M", expected: "PT1H30M"},
+		{name: "seconds into hours", dur: "PT3600S", expected: "PT1H"},
+		{name: "fractional seconds", dur: "PT61.5S", expected: "PT1M1.5S"},
+		{name: "hours are not carried by default", dur: "PT25H", expected: "PT25H"},
+		{name: "negative", dur: "-PT90M", expected: "-PT1H30M"},
+		{
+			name:     "hours into days",
+			dur:      "PT49H",
+			opts:     iso8601.NormalizeOptions{CarryHoursToDays: true},
+			expected: "P2DT1H",
+		},
+		{
+			name:     "days into weeks",
+			dur:      "P15D",
+			opts:     iso8601.NormalizeOptions{CarryDaysToWeeks: true},
+			expected: "P2W1D",
+		},
+		{
+			name:     "months into years",
+			dur:      "P25M",
+			opts:     iso8601.NormalizeOptions{CarryMonthsToYears: true},
+			expected: "P2Y1M",
+		},
+		{
+			name:     "spread fractional days",
+			dur:      "P1.5D",
+			opts:     iso8601.NormalizeOptions{SpreadFractions: true},
+			expected: "P1DT12H",
+		},
+		{
+			name:     "spread fractional years",
+			dur:      "P1.1Y",
+			opts:     iso8601.NormalizeOptions{SpreadFractions: true},
+			expected: "P1Y1M6D",
+		},
+		{
+			name:     "spread fractional months with custom month length",
+			dur:      "P0.5M",
+			opts:     iso8601.NormalizeOptions{SpreadFractions: true, DaysPerMonth: 28},
+			expected: "P14D",
+		},
+		{
+			name:     "spread fractional weeks",
+			dur:      "P1.5W",
+			opts:     iso8601.NormalizeOptions{SpreadFractions: true},
+			expected: "P1W3DT12H",
+		},
+		{
+			name:     "spread fractional minutes",
+			dur:      "PT0.123M",
+			opts:     iso8601.NormalizeOptions{SpreadFractions: true},
+			expected: "PT7.38S",
+		},
+		{
+			name: "everything",
+			dur:  "P13M6DT23H59M60S",
+			opts: iso8601.NormalizeOptions{
+				CarryHoursToDays:   true,
+				CarryDaysToWeeks:   true,
+				CarryMonthsToYears: true,
+			},
+			expected: "P1Y1M1W",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := mustDurationFromString(t, tc.dur).Normalize(tc.opts)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual.String())
+		})
+	}
+
+	t.Run("overflow", func(t *testing.T) {
+		_, err := mustDurationFromString(t, "P18446744073709551615Y12M").Normalize(
+			iso8601.NormalizeOptions{CarryMonthsToYears: true},
+		)
+		assert.ErrorIs(t, err, iso8601.ErrOverflow)
+	})
+}
+
+func TestDuration_ToTimeDuration(t *testing.T) {
+	testCases := []struct {
+		dur      string
+		expected time.Duration
+	}{
+		{dur: "PT0S", expected: 0},
+		{dur: "PT1H30M", expected: 90 * time.Minute},
+		{dur: "PT1.5H", expected: 90 * time.Minute},
+		{dur: "PT0.000000001S", expected: time.Nanosecond},
+		{dur: "-PT1M0.5S", expected: -60500 * time.Millisecond},
+		{dur: "P0Y0M0W0DT1S", expected: time.Second},
+		{dur: "PT2562047H47M16.854775807S", expected: math.MaxInt64},
+		{dur: "-PT2562047H47M16.854775808S", expected: math.MinInt64},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.dur, func(t *testing.T) {
+			actual, err := mustDurationFromString(t, tc.dur).ToTimeDuration()
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+
+	t.Run("date units", func(t *testing.T) {
+		for _, dur := range []string{"P1Y", "P1M", "P1W", "P1D", "P0.5D"} {
+			_, err := mustDurationFromString(t, dur).ToTimeDuration()
+			assert.ErrorIs(t, err, iso8601.ErrDateUnit, dur)
+		}
+	})
+	t.Run("overflow", func(t *testing.T) {
+		_, err := mustDurationFromString(t, "PT2562047H47M16.854775808S").ToTimeDuration()
+		assert.ErrorIs(t, err, iso8601.ErrOverflow)
+	})
+}
+
+func TestDuration_ToTimeDurationAt(t *testing.T) {
+	testCases := []struct {
+		name     string
+		dur      string
+		ref      time.Time
+		expected time.Duration
+	}{
+		{
+			name:     "month in january",
+			dur:      "P1M",
+			ref:      time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			expected: 31 * iso8601.TimeDay,
+		},
+		{
+			name:     "month in february",
+			dur:      "P1M",
+			ref:      time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+			expected: 28 * iso8601.TimeDay,
+		},
+		{
+			name:     "leap year",
+			dur:      "P1Y",
+			ref:      time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			expected: 366 * iso8601.TimeDay,
+		},
+		{
+			name:     "negative",
+			dur:      "-P1M1DT1H",
+			ref:      time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+			expected: -(29*iso8601.TimeDay + time.Hour),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := mustDurationFromString(t, tc.dur).ToTimeDurationAt(tc.ref)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+
+	t.Run("overflow", func(t *testing.T) {
+		_, err := mustDurationFromString(t, "P300Y").ToTimeDurationAt(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+		assert.ErrorIs(t, err, iso8601.ErrOverflow)
+	})
+	t.Run("fractional year", func(t *testing.T) {
+		_, err := mustDurationFromString(t, "P1.5Y").ToTimeDurationAt(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+		assert.Error(t, err)
+	})
+}
+
+func TestDuration_ToTimeDurationNominal(t *testing.T) {
+	testCases := []struct {
+		name     string
+		dur      string
+		parser   iso8601.Parser
+		expected time.Duration
+	}{
+		{
+			name:     "defaults",
+			dur:      "P1Y2M3W4DT5H6M7.8S",
+			expected: iso8601.TimeYear + 2*iso8601.TimeMonth + 3*iso8601.TimeWeek + 4*iso8601.TimeDay + 5*time.Hour + 6*time.Minute + 7800*time.Millisecond,
+		},
+		{
+			name:     "gregorian calendar",
+			dur:      "P1Y1M",
+			parser:   iso8601.Parser{YearLength: iso8601.TimeGregorianYear, MonthLength: iso8601.TimeGregorianMonth},
+			expected: iso8601.TimeGregorianYear + iso8601.TimeGregorianMonth,
+		},
+		{
+			name:     "fractional month",
+			dur:      "P0.5M",
+			expected: 15 * iso8601.TimeDay,
+		},
+		{
+			name:     "negative",
+			dur:      "-P1D",
+			expected: -iso8601.TimeDay,
+		},
+		{
+			name:     "zero calendar units",
+			dur:      "P0Y1D",
+			parser:   iso8601.Parser{CalendarUnits: iso8601.CalendarUnitsReject},
+			expected: iso8601.TimeDay,
+		},
+		{
+			name:     "clamped",
+			dur:      "-P1000Y",
+			parser:   iso8601.Parser{ClampOverflow: true},
+			expected: math.MinInt64,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := mustDurationFromString(t, tc.dur).ToTimeDurationNominal(tc.parser)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+
+	t.Run("calendar units", func(t *testing.T) {
+		_, err := mustDurationFromString(t, "P1M").ToTimeDurationNominal(iso8601.Parser{CalendarUnits: iso8601.CalendarUnitsError})
+		assert.ErrorIs(t, err, iso8601.ErrCalendarUnit)
+	})
+	t.Run("overflow", func(t *testing.T) {
+		_, err := mustDurationFromString(t, "P1000Y").ToTimeDurationNominal(iso8601.Parser{})
+		assert.ErrorIs(t, err, iso8601.ErrOverflow)
+	})
+}
+
+func TestDuration_SubtractFromTime(t *testing.T) {
+	testCases := []struct {
+		name     string
+		dur      string
+		stdTime  time.Time
+		expected time.Time
+	}{
+		{
+			name:     "month before the invoice date",
+			dur:      "P1M",
+			stdTime:  time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC),
+			expected: time.Date(2025, 2, 15, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "everything",
+			dur:      "P1Y1M1W1DT1H1M1.5S",
+			stdTime:  time.Date(2025, 3, 15, 12, 0, 0, 0, time.UTC),
+			expected: time.Date(2024, 2, 7, 10, 58, 58, 500*int(time.Millisecond), time.UTC),
+		},
+		{
+			name:     "negative duration",
+			dur:      "-P1DT1H",
+			stdTime:  time.Date(2025, 3, 15, 12, 0, 0, 0, time.UTC),
+			expected: time.Date(2025, 3, 16, 13, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := mustDurationFromString(t, tc.dur).SubtractFromTime(tc.stdTime)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestDuration_Resolve(t *testing.T) {
+	start := time.Date(2025, 1, 31, 8, 0, 0, 0, time.UTC)
+
+	end, err := mustDurationFromString(t, "P1M1DT2H").Resolve(start)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 3, 4, 10, 0, 0, 0, time.UTC), end)
+}
+
+func TestBetween(t *testing.T) {
+	testCases := []struct {
+		name     string
+		start    time.Time
+		end      time.Time
+		expected string
+	}{
+		{
+			name:     "same time",
+			start:    time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
+			end:      time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
+			expected: "PT0S",
+		},
+		{
+			name:     "one month",
+			start:    time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
+			end:      time.Date(2025, 2, 15, 0, 0, 0, 0, time.UTC),
+			expected: "P1M",
+		},
+		{
+			name:     "everything",
+			start:    time.Date(2023, 1, 15, 10, 20, 30, 0, time.UTC),
+			end:      time.Date(2025, 3, 20, 12, 25, 31, 500, time.UTC),
+			expected: "P2Y2M5DT2H5M1.0000005S",
+		},
+		{
+			name:     "end of month",
+			start:    time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC),
+			end:      time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+			expected: "P29D",
+		},
+		{
+			name:     "time of day before start",
+			start:    time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC),
+			end:      time.Date(2025, 2, 15, 11, 0, 0, 0, time.UTC),
+			expected: "P30DT23H",
+		},
+		{
+			name:     "negative",
+			start:    time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC),
+			end:      time.Date(2025, 2, 14, 23, 0, 0, 0, time.UTC),
+			expected: "-P1MT1H",
+		},
+		{
+			name:     "different locations",
+			start:    time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
+			end:      time.Date(2025, 1, 15, 3, 0, 0, 0, time.FixedZone("UTC+2", 2*60*60)),
+			expected: "PT1H",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := iso8601.Between(tc.start, tc.end)
+			assert.Equal(t, tc.expected, actual.String())
+
+			resolved, err := actual.Resolve(tc.start)
+			require.NoError(t, err)
+			assert.True(t, tc.end.Equal(resolved), "expected %s, actual %s", tc.end, resolved)
+		})
+	}
+}
+
+func TestBetween_ResolvesToEnd(t *testing.T) {
+	berlin := mustLoadLocation(t, "Europe/Berlin")
+	newYork := mustLoadLocation(t, "America/New_York")
+
+	starts := []time.Time{
+		time.Date(2024, 1, 31, 13, 0, 0, 0, time.UTC),
+		time.Date(2024, 10, 26, 2, 30, 0, 0, berlin),
+		time.Date(2024, 3, 30, 2, 30, 0, 0, berlin),
+		time.Date(2024, 10, 27, 1, 30, 0, 0, time.UTC).In(berlin),
+		time.Date(2024, 11, 3, 6, 30, 0, 0, time.UTC).In(newYork),
+	}
+
+	for _, start := range starts {
+		t.Run(start.Format(time.RFC3339), func(t *testing.T) {
+			for i := 0; i < 1000; i++ {
+				end := start.Add(time.Duration(i*i) * 37 * time.Minute)
+
+				forward, err := iso8601.Between(start, end).Resolve(start)
+				require.NoError(t, err)
+				require.True(t, end.Equal(forward), "forward: expected %s, actual %s", end, forward)
+
+				backward, err := iso8601.Between(end, start).Resolve(end)
+				require.NoError(t, err)
+				require.True(t, start.Equal(backward), "backward: expected %s, actual %s", start, backward)
+			}
+		})
+	}
+}
+
+func TestBetween_ResolvesToEnd_AmbiguousEnd(t *testing.T) {
+	berlin := mustLoadLocation(t, "Europe/Berlin")
+	start := time.Date(2024, 10, 26, 2, 30, 0, 0, berlin)
+	end := time.Date(2024, 10, 27, 1, 30, 0, 0, time.UTC).In(berlin) // 02:30 CET, after the clocks went back
+
+	between := iso8601.Between(start, end)
+	assert.Equal(t, "P1D", between.String())
+
+	actual, err := between.Resolve(start)
+	require.NoError(t, err)
+	assert.True(t, end.Equal(actual), "expected %s, actual %s", end, actual)
+}
+
 func TestDuration_String(t *testing.T) {
 	testCases := []struct {
 		name            string
@@ -371,6 +1208,16 @@ func TestDuration_String(t *testing.T) {
 			iso8601Duration: newDuration(t, true, 0, 0, 0, 0, 0, 0, 0),
 			expected:        "PT0S",
 		},
+		{
+			name:            "negative zero",
+			iso8601Duration: newDuration(t, false, 0, 0, 0, 0, 0, 0, 0),
+			expected:        "PT0S",
+		},
+		{
+			name:            "zero value",
+			iso8601Duration: iso8601.Duration{},
+			expected:        "PT0S",
+		},
 		{
 			name:            "3 nanoseconds",
 			iso8601Duration: newDuration(t, true, 0, 0, 0, 0, 0, 0, 0.000000003),
@@ -491,6 +1338,53 @@ func TestDuration_String(t *testing.T) {
 	}
 }
 
+func TestDuration_StringWithSeparator(t *testing.T) {
+	testCases := []struct {
+		name            string
+		iso8601Duration iso8601.Duration
+		separator       iso8601.DecimalSeparator
+		expected        string
+	}{
+		{
+			name:            "decimal point",
+			iso8601Duration: newDuration(t, true, 0, 0, 0, 0, 0, 0, 1.5),
+			separator:       iso8601.DecimalPoint,
+			expected:        "PT1.5S",
+		},
+		{
+			name:            "decimal comma",
+			iso8601Duration: newDuration(t, true, 0, 0, 0, 0, 0, 0, 1.5),
+			separator:       iso8601.DecimalComma,
+			expected:        "PT1,5S",
+		},
+		{
+			name:            "decimal comma on every unit",
+			iso8601Duration: newDuration(t, false, 1.5, 2.5, 3.5, 4.5, 5.5, 6.5, 7.5),
+			separator:       iso8601.DecimalComma,
+			expected:        "-P1,5Y2,5M3,5W4,5DT5,5H6,5M7,5S",
+		},
+		{
+			name:            "decimal comma without fraction",
+			iso8601Duration: newDuration(t, true, 0, 0, 0, 0, 1, 0, 0),
+			separator:       iso8601.DecimalComma,
+			expected:        "PT1H",
+		},
+		{
+			name:            "unknown separator",
+			iso8601Duration: newDuration(t, true, 0, 0, 0, 0, 0, 0, 1.5),
+			separator:       'x',
+			expected:        "PT1.5S",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := tc.iso8601Duration.StringWithSeparator(tc.separator)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
 func BenchmarkFormat_DurationStruct(b *testing.B) {
 	cases := []struct {
 		name string
@@ -531,6 +1425,7 @@ func BenchmarkParse_DurationStruct(b *testing.B) {
 	b.ResetTimer()
 	for _, benchCase := range cases {
 		b.Run(benchCase.name, func(b *testing.B) {
+			b.ReportAllocs()
 			for i := 0; i < b.N; i++ {
 				_, _ = iso8601.DurationFromString(benchCase.isoString)
 			}
@@ -0,0 +1,111 @@
+package iso8601
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// binaryVersion is the version of the binary encoding written by Duration.MarshalBinary.
+const binaryVersion = 1
+
+// binaryNegativeFlag marks a negative duration in the header of the binary encoding,
+// whose lower seven bits mark the components that are present.
+const binaryNegativeFlag = 1 << 7
+
+// MarshalText implements encoding.TextMarshaler by encoding the duration as ISO 8601 string, e.g. "P1DT12H".
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler by decoding an ISO 8601 duration string
+// using DurationFromString.
+func (d *Duration) UnmarshalText(text []byte) error {
+	out, err := DurationFromString(string(text))
+	if err != nil {
+		return err
+	}
+	*d = out
+
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler with a compact encoding that keeps the sign
+// and the exact value of every component.
+//
+// The encoding consists of a version byte, a header byte containing the sign in its highest bit and one bit
+// for each non-zero component from years in the lowest bit to seconds, followed by the whole part and
+// the fraction in units of 1e-9 of each non-zero component as unsigned varints.
+func (d Duration) MarshalBinary() ([]byte, error) {
+	header := byte(0)
+	if !d.isPositive {
+		header |= binaryNegativeFlag
+	}
+
+	out := make([]byte, 2, 2+7*2*binary.MaxVarintLen64)
+	for unit := yearUnit; unit <= secondUnit; unit++ {
+		value := d.unitValue(unit)
+		if value.isZero() {
+			continue
+		}
+
+		header |= 1 << (unit - yearUnit)
+		out = binary.AppendUvarint(out, value.whole)
+		out = binary.AppendUvarint(out, uint64(value.fraction))
+	}
+
+	out[0] = binaryVersion
+	out[1] = header
+
+	return out, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler by decoding the encoding of Duration.MarshalBinary.
+func (d *Duration) UnmarshalBinary(data []byte) error {
+	if len(data) < 2 {
+		return errors.New("iso8601: binary duration too short")
+	}
+	if data[0] != binaryVersion {
+		return errors.New("iso8601: unsupported binary duration version")
+	}
+
+	header := data[1]
+	out := Duration{isPositive: header&binaryNegativeFlag == 0}
+	data = data[2:]
+
+	for unit := yearUnit; unit <= secondUnit; unit++ {
+		if header&(1<<(unit-yearUnit)) == 0 {
+			continue
+		}
+
+		whole, n := binary.Uvarint(data)
+		if n <= 0 {
+			return errors.New("iso8601: invalid binary duration component")
+		}
+		data = data[n:]
+
+		fraction, n := binary.Uvarint(data)
+		if n <= 0 || fraction >= decimalFractionUnit {
+			return errors.New("iso8601: invalid binary duration component")
+		}
+		data = data[n:]
+
+		*out.unitValue(unit) = decimal{whole: whole, fraction: uint32(fraction)}
+	}
+
+	if len(data) > 0 {
+		return errors.New("iso8601: unexpected trailing bytes in binary duration")
+	}
+	*d = out
+
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder using the encoding of Duration.MarshalBinary.
+func (d Duration) GobEncode() ([]byte, error) {
+	return d.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder using the encoding of Duration.MarshalBinary.
+func (d *Duration) GobDecode(data []byte) error {
+	return d.UnmarshalBinary(data)
+}
@@ -0,0 +1,154 @@
+package iso8601_test
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"github.com/Achsion/iso8601/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+var (
+	_ encoding.TextMarshaler     = iso8601.Duration{}
+	_ encoding.TextUnmarshaler   = (*iso8601.Duration)(nil)
+	_ encoding.BinaryMarshaler   = iso8601.Duration{}
+	_ encoding.BinaryUnmarshaler = (*iso8601.Duration)(nil)
+	_ gob.GobEncoder             = iso8601.Duration{}
+	_ gob.GobDecoder             = (*iso8601.Duration)(nil)
+)
+
+// encodingFixtures are duration strings covering the sign, every component and exact fractions.
+var encodingFixtures = []string{
+	"PT0S",
+	"-PT0S",
+	"P1Y",
+	"-P1Y2M3W4DT5H6M7S",
+	"P1Y2M3W4DT5H6M7.123456789S",
+	"PT0.000000001S",
+	"P0.5Y",
+	"P1.1M",
+	"PT1,25H",
+	"P18446744073709551615D",
+	"-PT18446744073709551615.999999999S",
+}
+
+func TestDuration_MarshalText_RoundTrip(t *testing.T) {
+	for _, input := range encodingFixtures {
+		t.Run(input, func(t *testing.T) {
+			expected := mustDurationFromString(t, input)
+
+			text, err := expected.MarshalText()
+			require.NoError(t, err)
+			assert.Equal(t, expected.String(), string(text))
+
+			var actual iso8601.Duration
+			require.NoError(t, actual.UnmarshalText(text))
+			assert.True(t, expected.StrictEqual(actual))
+		})
+	}
+}
+
+func TestDuration_UnmarshalText_Error(t *testing.T) {
+	var actual iso8601.Duration
+	err := actual.UnmarshalText([]byte("P1X"))
+
+	var parseErr *iso8601.ParseError
+	require.ErrorAs(t, err, &parseErr)
+	assert.Equal(t, iso8601.ReasonInvalidDesignator, parseErr.Reason)
+}
+
+func TestDuration_MarshalText_MapKey(t *testing.T) {
+	input := map[iso8601.Duration]int{
+		mustDurationFromString(t, "PT1H"): 1,
+		mustDurationFromString(t, "P1D"):  2,
+	}
+
+	data, err := json.Marshal(input)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"PT1H":1,"P1D":2}`, string(data))
+
+	var actual map[iso8601.Duration]int
+	require.NoError(t, json.Unmarshal(data, &actual))
+	assert.Equal(t, input, actual)
+}
+
+func TestDuration_MarshalBinary_RoundTrip(t *testing.T) {
+	for _, input := range encodingFixtures {
+		t.Run(input, func(t *testing.T) {
+			expected := mustDurationFromString(t, input)
+
+			data, err := expected.MarshalBinary()
+			require.NoError(t, err)
+
+			var actual iso8601.Duration
+			require.NoError(t, actual.UnmarshalBinary(data))
+			assert.Equal(t, expected, actual)
+		})
+	}
+}
+
+func TestDuration_MarshalBinary(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected []byte
+	}{
+		{input: "PT0S", expected: []byte{1, 0}},
+		{input: "-PT0S", expected: []byte{1, 0x80}},
+		{input: "PT1H", expected: []byte{1, 0x10, 1, 0}},
+		{input: "-P1YT0.5S", expected: []byte{1, 0x80 | 0x41, 1, 0, 0, 0x80, 0xca, 0xb5, 0xee, 0x01}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			actual, err := mustDurationFromString(t, tc.input).MarshalBinary()
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestDuration_UnmarshalBinary_Error(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input []byte
+	}{
+		{name: "empty", input: nil},
+		{name: "missing header", input: []byte{1}},
+		{name: "unknown version", input: []byte{2, 0}},
+		{name: "missing component", input: []byte{1, 0x01}},
+		{name: "missing fraction", input: []byte{1, 0x01, 1}},
+		{name: "fraction out of range", input: []byte{1, 0x01, 1, 0x80, 0x94, 0xeb, 0xdc, 0x03}},
+		{name: "varint overflow", input: []byte{1, 0x01, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01, 0}},
+		{name: "trailing bytes", input: []byte{1, 0x10, 1, 0, 0}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var actual iso8601.Duration
+			assert.Error(t, actual.UnmarshalBinary(tc.input))
+		})
+	}
+}
+
+func TestDuration_Gob_RoundTrip(t *testing.T) {
+	type payload struct {
+		Name     string
+		Duration iso8601.Duration
+	}
+
+	for _, input := range encodingFixtures {
+		t.Run(input, func(t *testing.T) {
+			expected := payload{Name: input, Duration: mustDurationFromString(t, input)}
+
+			var buf bytes.Buffer
+			require.NoError(t, gob.NewEncoder(&buf).Encode(expected))
+
+			var actual payload
+			require.NoError(t, gob.NewDecoder(&buf).Decode(&actual))
+			assert.Equal(t, expected, actual)
+		})
+	}
+}
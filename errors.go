@@ -0,0 +1,155 @@
+package iso8601
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrOverflow is reported if a duration cannot be represented, as it is out of the supported range.
+// A ParseError with ReasonOverflow matches it with errors.Is.
+var ErrOverflow = errors.New("iso8601: duration out of range")
+
+// ErrMixedSigns is reported if the result of a Duration operation would contain components
+// with different signs, as a Duration only has a single sign for all its components.
+var ErrMixedSigns = errors.New("iso8601: duration components would have mixed signs")
+
+// ErrDateUnit is reported if a Duration containing years, months, weeks or days cannot be converted
+// into a time.Duration without a reference time or nominal unit lengths.
+var ErrDateUnit = errors.New("iso8601: date units years, months, weeks and days have no fixed length")
+
+// ErrAmbiguousComparison is reported if the result of comparing two durations depends on a reference time.
+var ErrAmbiguousComparison = errors.New("iso8601: comparison of durations is ambiguous without a reference time")
+
+// ErrCalendarUnit is reported if a duration contains years or months, but they are not supported.
+// A ParseError with ReasonCalendarUnit matches it with errors.Is.
+var ErrCalendarUnit = errors.New("iso8601: calendar units years and months are not supported")
+
+// ErrMonthEnd is reported if adding years or months to a date results in a day that does not exist
+// in the resulting month, e.g. February 30, and such days are not allowed.
+var ErrMonthEnd = errors.New("iso8601: day does not exist in the resulting month")
+
+// ErrLocalTime is reported if a local time does not exist or is ambiguous in its location,
+// e.g. due to daylight saving time, and such times are not allowed.
+var ErrLocalTime = errors.New("iso8601: local time does not exist or is ambiguous")
+
+// ErrUnanchoredInterval is reported if the start or end of an Interval consisting of a duration only is requested.
+var ErrUnanchoredInterval = errors.New("iso8601: interval has neither start nor end")
+
+// ParseErrorReason enumerates the reasons why parsing an ISO 8601 string can fail.
+type ParseErrorReason int
+
+const (
+	// ReasonMissingStartDesignator means that the duration does not start with 'P' or '-P'.
+	ReasonMissingStartDesignator ParseErrorReason = iota + 1
+	// ReasonInvalidDesignator means that an unknown designator (or any other unexpected character) was found.
+	ReasonInvalidDesignator
+	// ReasonDesignatorOutOfOrder means that a designator appears after a designator of a smaller unit.
+	ReasonDesignatorOutOfOrder
+	// ReasonDuplicateDesignator means that a designator appears more than once.
+	ReasonDuplicateDesignator
+	// ReasonMissingValue means that a designator is not preceded by a number.
+	ReasonMissingValue
+	// ReasonMissingDesignator means that a number is not followed by a designator.
+	ReasonMissingDesignator
+	// ReasonEmptyTimeSection means that the time designator 'T' is not followed by any time component.
+	ReasonEmptyTimeSection
+	// ReasonInvalidFraction means that a decimal fraction is malformed or not allowed at its position.
+	ReasonInvalidFraction
+	// ReasonOverflow means that a value is too large to be represented.
+	ReasonOverflow
+	// ReasonCalendarUnit means that a year or month designator was found, but calendar units are rejected.
+	ReasonCalendarUnit
+	// ReasonMissingDigits means that a fixed-width field of a date or time has too few digits.
+	ReasonMissingDigits
+	// ReasonFieldRange means that a field of a date or time is out of its range, e.g. month 13 or hour 25.
+	ReasonFieldRange
+	// ReasonMixedFormats means that a date or time mixes the basic and the extended format, e.g. "2024-0315".
+	ReasonMixedFormats
+	// ReasonInvalidInterval means that an interval is not a valid combination of start, end and duration,
+	// e.g. two durations, a negative duration or an end before the start.
+	ReasonInvalidInterval
+)
+
+// String returns a human-readable description of the reason.
+func (r ParseErrorReason) String() string {
+	switch r {
+	case ReasonMissingStartDesignator:
+		return "missing start designator 'P'"
+	case ReasonInvalidDesignator:
+		return "invalid designator"
+	case ReasonDesignatorOutOfOrder:
+		return "designator out of order"
+	case ReasonDuplicateDesignator:
+		return "duplicate designator"
+	case ReasonMissingValue:
+		return "missing value before designator"
+	case ReasonMissingDesignator:
+		return "missing designator after value"
+	case ReasonEmptyTimeSection:
+		return "empty time section"
+	case ReasonInvalidFraction:
+		return "invalid decimal fraction"
+	case ReasonOverflow:
+		return "value out of range"
+	case ReasonCalendarUnit:
+		return "calendar unit not allowed"
+	case ReasonMissingDigits:
+		return "missing digits"
+	case ReasonFieldRange:
+		return "field value out of range"
+	case ReasonMixedFormats:
+		return "mixed basic and extended format"
+	case ReasonInvalidInterval:
+		return "invalid interval"
+	}
+
+	return fmt.Sprintf("ParseErrorReason(%d)", int(r))
+}
+
+// ParseError describes a problem parsing an ISO 8601 string.
+// It is returned by all parse funcs of this package and can be retrieved with errors.As.
+type ParseError struct {
+	// Input is the complete string that was parsed.
+	Input string
+	// Offset is the byte offset into Input at which parsing failed.
+	Offset int
+	// Reason describes why parsing failed.
+	Reason ParseErrorReason
+}
+
+// Error returns the string representation of a ParseError.
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("iso8601: cannot parse %q at offset %d: %s", e.Input, e.Offset, e.Reason)
+}
+
+// Unwrap returns ErrOverflow if the reason of the error is ReasonOverflow
+// and ErrCalendarUnit if the reason is ReasonCalendarUnit.
+func (e *ParseError) Unwrap() error {
+	switch e.Reason {
+	case ReasonOverflow:
+		return ErrOverflow
+	case ReasonCalendarUnit:
+		return ErrCalendarUnit
+	}
+
+	return nil
+}
+
+func newParseError(input string, offset int, reason ParseErrorReason) *ParseError {
+	return &ParseError{
+		Input:  input,
+		Offset: offset,
+		Reason: reason,
+	}
+}
+
+// rebaseParseError converts a ParseError of the part of input starting at offset into a ParseError of input.
+// Any other error is returned as is.
+func rebaseParseError(err error, input string, offset int) error {
+	var parseErr *ParseError
+	if errors.As(err, &parseErr) {
+		return newParseError(input, offset+parseErr.Offset, parseErr.Reason)
+	}
+
+	return err
+}
@@ -0,0 +1,159 @@
+package iso8601_test
+
+import (
+	"errors"
+	"github.com/Achsion/iso8601/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestParseError(t *testing.T) {
+	testCases := []struct {
+		name           string
+		isoStr         string
+		expectedOffset int
+		expectedReason iso8601.ParseErrorReason
+	}{
+		{
+			name:           "missing 'P' prefix",
+			isoStr:         "1Y2M3DT4H5M6S",
+			expectedOffset: 0,
+			expectedReason: iso8601.ReasonMissingStartDesignator,
+		},
+		{
+			name:           "missing 'P' prefix after negative sign",
+			isoStr:         "-1Y",
+			expectedOffset: 1,
+			expectedReason: iso8601.ReasonMissingStartDesignator,
+		},
+		{
+			name:           "invalid designator",
+			isoStr:         "P1Y2M40G1D",
+			expectedOffset: 7,
+			expectedReason: iso8601.ReasonInvalidDesignator,
+		},
+		{
+			name:           "wrong order of designators",
+			isoStr:         "PT5M4H6S",
+			expectedOffset: 5,
+			expectedReason: iso8601.ReasonDesignatorOutOfOrder,
+		},
+		{
+			name:           "double designator",
+			isoStr:         "P1Y2M3DT4H3H5M6S",
+			expectedOffset: 11,
+			expectedReason: iso8601.ReasonDuplicateDesignator,
+		},
+		{
+			name:           "double time designator",
+			isoStr:         "PT1HT1M",
+			expectedOffset: 4,
+			expectedReason: iso8601.ReasonDuplicateDesignator,
+		},
+		{
+			name:           "designator without value",
+			isoStr:         "P1YM",
+			expectedOffset: 3,
+			expectedReason: iso8601.ReasonMissingValue,
+		},
+		{
+			name:           "value without designator",
+			isoStr:         "P7Y3M4D1",
+			expectedOffset: 8,
+			expectedReason: iso8601.ReasonMissingDesignator,
+		},
+		{
+			name:           "empty time section",
+			isoStr:         "P1DT",
+			expectedOffset: 4,
+			expectedReason: iso8601.ReasonEmptyTimeSection,
+		},
+		{
+			name:           "fraction on non lowest-order unit",
+			isoStr:         "PT1.5H30M",
+			expectedOffset: 6,
+			expectedReason: iso8601.ReasonInvalidFraction,
+		},
+		{
+			name:           "fraction without integer part",
+			isoStr:         "PT.5S",
+			expectedOffset: 2,
+			expectedReason: iso8601.ReasonInvalidFraction,
+		},
+		{
+			name:           "fraction without designator",
+			isoStr:         "PT1.",
+			expectedOffset: 4,
+			expectedReason: iso8601.ReasonInvalidFraction,
+		},
+		{
+			name:           "no components",
+			isoStr:         "P",
+			expectedOffset: 1,
+			expectedReason: iso8601.ReasonMissingValue,
+		},
+		{
+			name:           "fraction with multiple decimal points",
+			isoStr:         "P1..2Y",
+			expectedOffset: 3,
+			expectedReason: iso8601.ReasonInvalidFraction,
+		},
+		{
+			name:           "fraction without digits",
+			isoStr:         "P.Y",
+			expectedOffset: 1,
+			expectedReason: iso8601.ReasonInvalidFraction,
+		},
+		{
+			name:           "fraction with multiple fraction parts",
+			isoStr:         "P1.2.3D",
+			expectedOffset: 4,
+			expectedReason: iso8601.ReasonInvalidFraction,
+		},
+		{
+			name:           "fraction on date component followed by time component",
+			isoStr:         "P1.5DT2H",
+			expectedOffset: 6,
+			expectedReason: iso8601.ReasonInvalidFraction,
+		},
+		{
+			name:           "only time designator",
+			isoStr:         "PT",
+			expectedOffset: 2,
+			expectedReason: iso8601.ReasonEmptyTimeSection,
+		},
+	}
+
+	parsers := map[string]func(string) error{
+		"ParseToDuration": func(isoStr string) error {
+			_, err := iso8601.ParseToDuration(isoStr)
+			return err
+		},
+		"DurationFromString": func(isoStr string) error {
+			_, err := iso8601.DurationFromString(isoStr)
+			return err
+		},
+	}
+
+	for parserName, parse := range parsers {
+		for _, tc := range testCases {
+			t.Run(parserName+"/"+tc.name, func(t *testing.T) {
+				err := parse(tc.isoStr)
+
+				var parseErr *iso8601.ParseError
+				require.True(t, errors.As(err, &parseErr))
+				assert.Equal(t, tc.isoStr, parseErr.Input)
+				assert.Equal(t, tc.expectedOffset, parseErr.Offset)
+				assert.Equal(t, tc.expectedReason, parseErr.Reason)
+			})
+		}
+	}
+}
+
+func TestParseError_Error(t *testing.T) {
+	_, err := iso8601.ParseToDuration("PT5M4H")
+	require.Error(t, err)
+
+	assert.Equal(t, `iso8601: cannot parse "PT5M4H" at offset 5: designator out of order`, err.Error())
+}
@@ -0,0 +1,269 @@
+package iso8601
+
+import (
+	"strings"
+	"time"
+)
+
+// Interval is an ISO 8601 time interval in one of its four forms:
+// start and end "2024-01-01/2024-01-31", start and duration "2024-01-01T00:00Z/P1M",
+// duration and end "P1D/2024-02-01" or a duration only "P1D".
+// The start is inclusive and the end is exclusive.
+type Interval struct {
+	start    intervalPoint
+	end      intervalPoint
+	duration Duration
+
+	hasStart    bool
+	hasEnd      bool
+	hasDuration bool
+}
+
+// intervalPoint is the start or end of an Interval.
+type intervalPoint struct {
+	time time.Time
+	// isDate reports whether the point has been given as calendar date without a time of day
+	isDate bool
+}
+
+// IntervalFromStartEnd creates an Interval from its start and end.
+func IntervalFromStartEnd(start, end time.Time) Interval {
+	return Interval{start: intervalPoint{time: start}, end: intervalPoint{time: end}, hasStart: true, hasEnd: true}
+}
+
+// IntervalFromStartDuration creates an Interval from its start and duration.
+func IntervalFromStartDuration(start time.Time, duration Duration) Interval {
+	return Interval{start: intervalPoint{time: start}, duration: duration, hasStart: true, hasDuration: true}
+}
+
+// IntervalFromDurationEnd creates an Interval from its duration and end.
+func IntervalFromDurationEnd(duration Duration, end time.Time) Interval {
+	return Interval{duration: duration, end: intervalPoint{time: end}, hasDuration: true, hasEnd: true}
+}
+
+// IntervalFromDuration creates an Interval from its duration only, which has neither start nor end.
+func IntervalFromDuration(duration Duration) Interval {
+	return Interval{duration: duration, hasDuration: true}
+}
+
+// ParseInterval parses an ISO 8601 time interval in any of its four forms, separated by either '/' or "--",
+// e.g. "2024-01-01T00:00Z/P1M", "P1D/2024-02-01", "2024-01-01/2024-01-31" or "P1D".
+// Start and end are either calendar dates following the rules of ParseDate,
+// or dates with a time of day following the rules of ParseDateTime.
+// The duration follows the rules of DurationFromString, but must not be negative.
+func ParseInterval(intervalString string) (Interval, error) {
+	return parseInterval(intervalString, 0)
+}
+
+// parseInterval parses the part of input starting at offset as Interval.
+func parseInterval(input string, offset int) (Interval, error) {
+	intervalString := input[offset:]
+
+	separatorIdx := strings.IndexByte(intervalString, intervalSeparator)
+	separatorLen := 1
+	if separatorIdx < 0 {
+		separatorIdx = strings.Index(intervalString, intervalAlternateSeparator)
+		separatorLen = len(intervalAlternateSeparator)
+	}
+
+	if separatorIdx < 0 {
+		duration, err := parseIntervalDuration(input, offset, intervalString)
+		if err != nil {
+			return Interval{}, err
+		}
+
+		return IntervalFromDuration(duration), nil
+	}
+
+	firstOffset := offset
+	secondOffset := offset + separatorIdx + separatorLen
+	first := intervalString[:separatorIdx]
+	second := intervalString[separatorIdx+separatorLen:]
+
+	isFirstDuration := isIntervalDuration(first)
+	isSecondDuration := isIntervalDuration(second)
+
+	out := Interval{}
+	var err error
+	switch {
+	case isFirstDuration && isSecondDuration:
+		return Interval{}, newParseError(input, secondOffset, ReasonInvalidInterval)
+	case isFirstDuration:
+		out.duration, err = parseIntervalDuration(input, firstOffset, first)
+		if err != nil {
+			return Interval{}, err
+		}
+		out.end, err = parseIntervalPoint(input, secondOffset, second)
+		if err != nil {
+			return Interval{}, err
+		}
+		out.hasDuration, out.hasEnd = true, true
+	case isSecondDuration:
+		out.start, err = parseIntervalPoint(input, firstOffset, first)
+		if err != nil {
+			return Interval{}, err
+		}
+		out.duration, err = parseIntervalDuration(input, secondOffset, second)
+		if err != nil {
+			return Interval{}, err
+		}
+		out.hasStart, out.hasDuration = true, true
+	default:
+		out.start, err = parseIntervalPoint(input, firstOffset, first)
+		if err != nil {
+			return Interval{}, err
+		}
+		out.end, err = parseIntervalPoint(input, secondOffset, second)
+		if err != nil {
+			return Interval{}, err
+		}
+		if out.end.time.Before(out.start.time) {
+			return Interval{}, newParseError(input, secondOffset, ReasonInvalidInterval)
+		}
+		out.hasStart, out.hasEnd = true, true
+	}
+
+	return out, nil
+}
+
+// isIntervalDuration reports whether the part of an interval is a duration instead of a date.
+func isIntervalDuration(part string) bool {
+	return strings.HasPrefix(part, "P") || strings.HasPrefix(part, "-P")
+}
+
+// parseIntervalDuration parses part, which starts at offset in input, as non-negative duration of an interval.
+func parseIntervalDuration(input string, offset int, part string) (Duration, error) {
+	duration, err := DurationFromString(part)
+	if err != nil {
+		return Duration{}, rebaseParseError(err, input, offset)
+	}
+	if !duration.isPositive && !duration.IsZero() {
+		return Duration{}, newParseError(input, offset, ReasonInvalidInterval)
+	}
+
+	return duration, nil
+}
+
+// parseIntervalPoint parses part, which starts at offset in input, as start or end of an interval.
+func parseIntervalPoint(input string, offset int, part string) (intervalPoint, error) {
+	if strings.IndexByte(part, timeSwitchDesignator) < 0 {
+		date, err := ParseDate(part)
+		if err != nil {
+			return intervalPoint{}, rebaseParseError(err, input, offset)
+		}
+
+		return intervalPoint{time: date, isDate: true}, nil
+	}
+
+	dateTime, err := ParseDateTime(part)
+	if err != nil {
+		return intervalPoint{}, rebaseParseError(err, input, offset)
+	}
+
+	return intervalPoint{time: dateTime}, nil
+}
+
+// Start returns the start of the interval. For the duration and end form, the duration is subtracted from the end.
+// It returns ErrUnanchoredInterval if the interval consists of a duration only.
+func (i Interval) Start() (time.Time, error) {
+	switch {
+	case i.hasStart:
+		return i.start.time, nil
+	case i.hasEnd:
+		return i.duration.SubtractFromTime(i.end.time)
+	}
+
+	return time.Time{}, ErrUnanchoredInterval
+}
+
+// End returns the end of the interval. For the start and duration form, the duration is added to the start.
+// It returns ErrUnanchoredInterval if the interval consists of a duration only.
+func (i Interval) End() (time.Time, error) {
+	switch {
+	case i.hasEnd:
+		return i.end.time, nil
+	case i.hasStart:
+		return i.duration.AddToTime(i.start.time)
+	}
+
+	return time.Time{}, ErrUnanchoredInterval
+}
+
+// Duration returns the duration of the interval. For the start and end form, it is calculated using Between.
+func (i Interval) Duration() Duration {
+	if i.hasDuration {
+		return i.duration
+	}
+
+	return Between(i.start.time, i.end.time)
+}
+
+// Contains reports whether t is within the interval, including its start but excluding its end.
+// It returns ErrUnanchoredInterval if the interval consists of a duration only.
+func (i Interval) Contains(t time.Time) (bool, error) {
+	start, err := i.Start()
+	if err != nil {
+		return false, err
+	}
+	end, err := i.End()
+	if err != nil {
+		return false, err
+	}
+
+	return !t.Before(start) && t.Before(end), nil
+}
+
+// String returns the ISO 8601 representation of the interval in the same form it has been created with,
+// separated by '/'. It can be parsed by ParseInterval into an equal interval.
+func (i Interval) String() string {
+	buf := make([]byte, 0, 64)
+
+	if i.hasStart {
+		buf = i.start.appendTo(buf)
+	}
+	if i.hasDuration {
+		if i.hasStart {
+			buf = append(buf, intervalSeparator)
+		}
+		buf = append(buf, i.duration.String()...)
+	}
+	if i.hasEnd {
+		if i.hasStart || i.hasDuration {
+			buf = append(buf, intervalSeparator)
+		}
+		buf = i.end.appendTo(buf)
+	}
+
+	return string(buf)
+}
+
+// appendTo appends the point in the extended format to buf, with the time of day down to its smallest non-zero field.
+func (p intervalPoint) appendTo(buf []byte) []byte {
+	if p.isDate {
+		return appendDate(buf, p.time, FormatExtended)
+	}
+
+	buf = appendDate(buf, p.time, FormatExtended)
+	buf = append(buf, timeSwitchDesignator)
+
+	return appendTime(buf, p.time, FormatExtended, shortestPrecision(p.time))
+}
+
+// shortestPrecision returns the smallest TimePrecision that formats the time of day of t without truncation,
+// but at least PrecisionMinute.
+func shortestPrecision(t time.Time) TimePrecision {
+	nanosecond := t.Nanosecond()
+
+	switch {
+	case nanosecond%1000 != 0:
+		return PrecisionNanosecond
+	case nanosecond%1_000_000 != 0:
+		return PrecisionMicrosecond
+	case nanosecond != 0:
+		return PrecisionMillisecond
+	case t.Second() != 0:
+		return PrecisionSecond
+	}
+
+	return PrecisionMinute
+}
@@ -0,0 +1,168 @@
+package iso8601_test
+
+import (
+	"github.com/Achsion/iso8601/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestParseInterval(t *testing.T) {
+	testCases := []struct {
+		input            string
+		expectedStart    time.Time
+		expectedEnd      time.Time
+		expectedDuration string
+		expectedString   string
+	}{
+		{
+			input:            "2024-01-01T00:00Z/P1M",
+			expectedStart:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			expectedEnd:      time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			expectedDuration: "P1M",
+			expectedString:   "2024-01-01T00:00Z/P1M",
+		},
+		{
+			input:            "P1D/2024-02-01",
+			expectedStart:    time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+			expectedEnd:      time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			expectedDuration: "P1D",
+			expectedString:   "P1D/2024-02-01",
+		},
+		{
+			input:            "2024-01-01/2024-01-31",
+			expectedStart:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			expectedEnd:      time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+			expectedDuration: "P30D",
+			expectedString:   "2024-01-01/2024-01-31",
+		},
+		{
+			input:            "2024-01-01--2024-01-31",
+			expectedStart:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			expectedEnd:      time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+			expectedDuration: "P30D",
+			expectedString:   "2024-01-01/2024-01-31",
+		},
+		{
+			input:            "20240101T0900-0500--PT1H30M",
+			expectedStart:    time.Date(2024, 1, 1, 14, 0, 0, 0, time.UTC),
+			expectedEnd:      time.Date(2024, 1, 1, 15, 30, 0, 0, time.UTC),
+			expectedDuration: "PT1H30M",
+			expectedString:   "2024-01-01T09:00-05:00/PT1H30M",
+		},
+		{
+			input:            "2024-01-01T09:00:00.5Z/2024-01-01T10:15:30Z",
+			expectedStart:    time.Date(2024, 1, 1, 9, 0, 0, 500000000, time.UTC),
+			expectedEnd:      time.Date(2024, 1, 1, 10, 15, 30, 0, time.UTC),
+			expectedDuration: "PT1H15M29.5S",
+			expectedString:   "2024-01-01T09:00:00.500Z/2024-01-01T10:15:30Z",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			interval, err := iso8601.ParseInterval(tc.input)
+			require.NoError(t, err)
+
+			start, err := interval.Start()
+			require.NoError(t, err)
+			assert.True(t, tc.expectedStart.Equal(start), "expected start %s, actual %s", tc.expectedStart, start)
+
+			end, err := interval.End()
+			require.NoError(t, err)
+			assert.True(t, tc.expectedEnd.Equal(end), "expected end %s, actual %s", tc.expectedEnd, end)
+
+			assert.Equal(t, tc.expectedDuration, interval.Duration().String())
+			assert.Equal(t, tc.expectedString, interval.String())
+
+			reparsed, err := iso8601.ParseInterval(interval.String())
+			require.NoError(t, err)
+			assert.Equal(t, interval.String(), reparsed.String())
+		})
+	}
+}
+
+func TestParseInterval_DurationOnly(t *testing.T) {
+	interval, err := iso8601.ParseInterval("P1D")
+	require.NoError(t, err)
+
+	assert.Equal(t, "P1D", interval.String())
+	assert.Equal(t, "P1D", interval.Duration().String())
+
+	_, err = interval.Start()
+	assert.ErrorIs(t, err, iso8601.ErrUnanchoredInterval)
+	_, err = interval.End()
+	assert.ErrorIs(t, err, iso8601.ErrUnanchoredInterval)
+	_, err = interval.Contains(time.Now())
+	assert.ErrorIs(t, err, iso8601.ErrUnanchoredInterval)
+}
+
+func TestParseInterval_Error(t *testing.T) {
+	testCases := []struct {
+		name           string
+		input          string
+		expectedOffset int
+		expectedReason iso8601.ParseErrorReason
+	}{
+		{"empty", "", 0, iso8601.ReasonMissingStartDesignator},
+		{"date only", "2024-01-01", 0, iso8601.ReasonMissingStartDesignator},
+		{"two durations", "P1D/P2D", 4, iso8601.ReasonInvalidInterval},
+		{"negative duration", "2024-01-01/-P1D", 11, iso8601.ReasonInvalidInterval},
+		{"end before start", "2024-01-31/2024-01-01", 11, iso8601.ReasonInvalidInterval},
+		{"invalid start", "2024-13-01/P1D", 5, iso8601.ReasonFieldRange},
+		{"invalid end", "P1D/2024-01-01T25:00Z", 15, iso8601.ReasonFieldRange},
+		{"invalid duration", "2024-01-01/P1X", 13, iso8601.ReasonInvalidDesignator},
+		{"invalid duration after double hyphen", "2024-01-01--P1X", 14, iso8601.ReasonInvalidDesignator},
+		{"missing end", "2024-01-01/", 11, iso8601.ReasonMissingDigits},
+		{"three parts", "2024-01-01/P1D/2024-01-02", 14, iso8601.ReasonInvalidDesignator},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := iso8601.ParseInterval(tc.input)
+
+			var parseErr *iso8601.ParseError
+			require.ErrorAs(t, err, &parseErr)
+			assert.Equal(t, tc.input, parseErr.Input)
+			assert.Equal(t, tc.expectedOffset, parseErr.Offset)
+			assert.Equal(t, tc.expectedReason, parseErr.Reason)
+		})
+	}
+}
+
+func TestInterval_Contains(t *testing.T) {
+	interval, err := iso8601.ParseInterval("2024-01-31T00:00Z/P1M")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		stdTime  time.Time
+		expected bool
+	}{
+		{"before start", time.Date(2024, 1, 30, 23, 59, 59, 0, time.UTC), false},
+		{"start", time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), true},
+		{"within", time.Date(2024, 2, 29, 12, 0, 0, 0, time.UTC), true},
+		{"just before end", time.Date(2024, 3, 1, 23, 59, 59, 999999999, time.UTC), true},
+		{"end", time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC), false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := interval.Contains(tc.stdTime)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestInterval_Constructors(t *testing.T) {
+	start := time.Date(2024, 1, 1, 9, 30, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 2, 9, 30, 0, 0, time.UTC)
+	duration := mustDurationFromString(t, "P1D")
+
+	assert.Equal(t, "2024-01-01T09:30Z/2024-01-02T09:30Z", iso8601.IntervalFromStartEnd(start, end).String())
+	assert.Equal(t, "2024-01-01T09:30Z/P1D", iso8601.IntervalFromStartDuration(start, duration).String())
+	assert.Equal(t, "P1D/2024-01-02T09:30Z", iso8601.IntervalFromDurationEnd(duration, end).String())
+	assert.Equal(t, "P1D", iso8601.IntervalFromDuration(duration).String())
+}
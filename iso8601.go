@@ -1,3 +1,4 @@
 // Package iso8601 is a utility for parsing and formatting ISO8601 duration strings from and into
 // native Go time.Duration, as the standard library does not support ISO 8601 durations.
+// It also parses and formats ISO 8601 calendar dates and times of day from and into time.Time.
 package iso8601
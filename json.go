@@ -0,0 +1,156 @@
+package iso8601
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// jsonNull is the JSON literal null, which leaves a value unchanged when unmarshalling, as usual for encoding/json.
+var jsonNull = []byte("null")
+
+// MarshalJSON implements json.Marshaler by encoding the duration as ISO 8601 string, e.g. "P1DT12H".
+func (d Duration) MarshalJSON() ([]byte, error) {
+	buf := make([]byte, 0, 32)
+	buf = append(buf, '"')
+	buf = append(buf, d.String()...)
+
+	return append(buf, '"'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler by decoding an ISO 8601 duration string using DurationFromString.
+// Invalid values result in a *json.UnmarshalTypeError describing the JSON value and the cause, which the
+// encoding/json decoder completes with the struct field the value belongs to. With the jsonv2 experiment, which
+// returns errors of UnmarshalJSON unchanged, UnmarshalJSONFrom provides the field instead.
+// Use LenientDuration to also accept a number of seconds.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	if err := d.decodeJSON(data); err != nil {
+		return err.typeError()
+	}
+
+	return nil
+}
+
+// decodeJSON decodes the JSON value data like UnmarshalJSON.
+func (d *Duration) decodeJSON(data []byte) *jsonDecodeError {
+	if bytes.Equal(data, jsonNull) {
+		return nil
+	}
+
+	if len(data) == 0 || data[0] != '"' {
+		return &jsonDecodeError{data: data, typ: reflect.TypeFor[Duration]()}
+	}
+
+	return d.decodeJSONString(data, reflect.TypeFor[Duration]())
+}
+
+// decodeJSONString decodes the JSON string data as ISO 8601 duration, reporting errors for the type typ.
+func (d *Duration) decodeJSONString(data []byte, typ reflect.Type) *jsonDecodeError {
+	var durationString string
+	if err := json.Unmarshal(data, &durationString); err != nil {
+		return &jsonDecodeError{data: data, typ: typ}
+	}
+
+	out, err := DurationFromString(durationString)
+	if err != nil {
+		return &jsonDecodeError{data: data, typ: typ, cause: err}
+	}
+	*d = out
+
+	return nil
+}
+
+// LenientDuration is a Duration that additionally accepts a JSON number of seconds when unmarshalling,
+// e.g. 90.5 for "PT90.5S", for clients that do not send ISO 8601 strings yet.
+// It is still marshalled as ISO 8601 string.
+type LenientDuration struct {
+	Duration
+}
+
+// UnmarshalJSON implements json.Unmarshaler like Duration.UnmarshalJSON, but also accepts a number of seconds.
+func (d *LenientDuration) UnmarshalJSON(data []byte) error {
+	if err := d.decodeJSON(data); err != nil {
+		return err.typeError()
+	}
+
+	return nil
+}
+
+// decodeJSON decodes the JSON value data like UnmarshalJSON.
+func (d *LenientDuration) decodeJSON(data []byte) *jsonDecodeError {
+	if bytes.Equal(data, jsonNull) {
+		return nil
+	}
+
+	if len(data) > 0 && data[0] == '"' {
+		return d.decodeJSONString(data, reflect.TypeFor[LenientDuration]())
+	}
+
+	var number json.Number
+	if err := json.Unmarshal(data, &number); err != nil {
+		return &jsonDecodeError{data: data, typ: reflect.TypeFor[LenientDuration]()}
+	}
+
+	out, err := durationFromSeconds(number.String())
+	if err != nil {
+		return &jsonDecodeError{data: data, typ: reflect.TypeFor[LenientDuration](), cause: err}
+	}
+	d.Duration = out
+
+	return nil
+}
+
+// durationFromSeconds converts a JSON number of seconds into a Duration containing seconds only.
+// Numbers without exponent are converted exactly.
+func durationFromSeconds(number string) (Duration, error) {
+	if strings.ContainsAny(number, "eE") {
+		value, err := strconv.ParseFloat(number, 64)
+		if err != nil {
+			return Duration{}, ErrOverflow
+		}
+		number = strconv.FormatFloat(value, 'f', -1, 64)
+	}
+
+	sign := ""
+	if strings.HasPrefix(number, "-") {
+		sign = "-"
+		number = number[1:]
+	}
+
+	return DurationFromString(sign + "PT" + number + "S")
+}
+
+// jsonDecodeError describes a JSON value data that could not be decoded into typ because of cause,
+// which is nil if the value has the wrong kind.
+type jsonDecodeError struct {
+	data  []byte
+	typ   reflect.Type
+	cause error
+}
+
+// typeError returns the *json.UnmarshalTypeError of e, which describes the cause within its Value.
+func (e *jsonDecodeError) typeError() error {
+	value := "value"
+	switch {
+	case len(e.data) == 0:
+	case e.data[0] == '"':
+		value = "string " + string(e.data)
+	case e.data[0] == '-' || isDigit(e.data[0]):
+		value = "number " + string(e.data)
+	case e.data[0] == '{':
+		value = "object"
+	case e.data[0] == '[':
+		value = "array"
+	case e.data[0] == 't' || e.data[0] == 'f':
+		value = "bool"
+	}
+
+	if e.cause != nil {
+		value = fmt.Sprintf("%s (%v)", value, e.cause)
+	}
+
+	return &json.UnmarshalTypeError{Value: value, Type: e.typ}
+}
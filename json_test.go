@@ -0,0 +1,163 @@
+package iso8601_test
+
+import (
+	"encoding/json"
+	"github.com/Achsion/iso8601/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+type jsonConfig struct {
+	Timeout iso8601.Duration                    `json:"timeout"`
+	Retry   iso8601.LenientDuration             `json:"retry"`
+	Backoff *iso8601.Duration                   `json:"backoff,omitempty"`
+	Nested  struct{ Interval iso8601.Duration } `json:"nested"`
+}
+
+func TestDuration_MarshalJSON(t *testing.T) {
+	backoff := mustDurationFromString(t, "-PT0.5S")
+	config := jsonConfig{
+		Timeout: mustDurationFromString(t, "P1DT12H"),
+		Retry:   iso8601.LenientDuration{Duration: mustDurationFromString(t, "PT90S")},
+		Backoff: &backoff,
+	}
+
+	actual, err := json.Marshal(config)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"timeout":"P1DT12H","retry":"PT90S","backoff":"-PT0.5S","nested":{"Interval":"PT0S"}}`, string(actual))
+}
+
+func TestDuration_UnmarshalJSON(t *testing.T) {
+	var config jsonConfig
+	err := json.Unmarshal(
+		[]byte(`{"timeout":"P1Y2M3W4DT5H6M7.5S","retry":"PT1M","backoff":"-P1D","nested":{"Interval":"PT1,5H"}}`),
+		&config,
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "P1Y2M3W4DT5H6M7.5S", config.Timeout.String())
+	assert.Equal(t, "PT1M", config.Retry.String())
+	require.NotNil(t, config.Backoff)
+	assert.Equal(t, "-P1D", config.Backoff.String())
+	assert.Equal(t, "PT1.5H", config.Nested.Interval.String())
+}
+
+func TestDuration_UnmarshalJSON_Null(t *testing.T) {
+	config := jsonConfig{Timeout: mustDurationFromString(t, "PT1H")}
+
+	err := json.Unmarshal([]byte(`{"timeout":null,"backoff":null}`), &config)
+	require.NoError(t, err)
+	assert.Equal(t, "PT1H", config.Timeout.String())
+	assert.Nil(t, config.Backoff)
+}
+
+func TestDuration_UnmarshalJSON_RoundTrip(t *testing.T) {
+	for _, input := range []string{"P1Y", "-P1M2D", "PT0.000000001S", "P1W", "PT1.5H"} {
+		t.Run(input, func(t *testing.T) {
+			data, err := json.Marshal(mustDurationFromString(t, input))
+			require.NoError(t, err)
+
+			var actual iso8601.Duration
+			require.NoError(t, json.Unmarshal(data, &actual))
+			assert.True(t, mustDurationFromString(t, input).StrictEqual(actual))
+		})
+	}
+}
+
+func TestDuration_UnmarshalJSON_Error(t *testing.T) {
+	testCases := []struct {
+		name          string
+		input         string
+		expectedValue string
+		expectedCause string
+		expectedField string
+	}{
+		{
+			name:          "invalid duration",
+			input:         `{"timeout":"P1X"}`,
+			expectedValue: `string "P1X"`,
+			expectedCause: `iso8601: cannot parse "P1X" at offset 2: invalid designator`,
+			expectedField: "timeout",
+		},
+		{
+			name:          "number without opt-in",
+			input:         `{"timeout":90}`,
+			expectedValue: "number 90",
+			expectedField: "timeout",
+		},
+		{
+			name:          "bool",
+			input:         `{"timeout":true}`,
+			expectedValue: "bool",
+			expectedField: "timeout",
+		},
+		{
+			name:          "nested field",
+			input:         `{"nested":{"Interval":"1h"}}`,
+			expectedValue: `string "1h"`,
+			expectedCause: `iso8601: cannot parse "1h" at offset 0: missing start designator 'P'`,
+			expectedField: "nested.Interval",
+		},
+		{
+			name:          "lenient invalid duration",
+			input:         `{"retry":"P"}`,
+			expectedValue: `string "P"`,
+			expectedCause: `iso8601: cannot parse "P" at offset 1: missing value before designator`,
+			expectedField: "retry",
+		},
+		{
+			name:          "lenient number out of range",
+			input:         `{"retry":1e999}`,
+			expectedValue: "number 1e999",
+			expectedCause: "iso8601: duration out of range",
+			expectedField: "retry",
+		},
+		{
+			name:          "lenient object",
+			input:         `{"retry":{}}`,
+			expectedValue: "object",
+			expectedField: "retry",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var config jsonConfig
+			err := json.Unmarshal([]byte(tc.input), &config)
+
+			var typeErr *json.UnmarshalTypeError
+			require.ErrorAs(t, err, &typeErr)
+			// the cause is part of the value or of the error, depending on the encoding/json implementation
+			assert.True(t, strings.HasPrefix(typeErr.Value, tc.expectedValue), typeErr.Value)
+			assert.Contains(t, err.Error(), tc.expectedCause)
+
+			assert.Equal(t, tc.expectedField, typeErr.Field)
+			assert.Contains(t, err.Error(), "."+tc.expectedField+" of type")
+		})
+	}
+}
+
+func TestLenientDuration_UnmarshalJSON(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{input: `"PT1H"`, expected: "PT1H"},
+		{input: `90`, expected: "PT90S"},
+		{input: `90.5`, expected: "PT90.5S"},
+		{input: `0.1`, expected: "PT0.1S"},
+		{input: `-2`, expected: "-PT2S"},
+		{input: `1e3`, expected: "PT1000S"},
+		{input: `1.5E-3`, expected: "PT0.0015S"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			var actual iso8601.LenientDuration
+			require.NoError(t, json.Unmarshal([]byte(tc.input), &actual))
+			assert.Equal(t, tc.expected, actual.String())
+		})
+	}
+}
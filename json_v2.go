@@ -0,0 +1,55 @@
+//go:build go1.27 && goexperiment.jsonv2
+
+package iso8601
+
+import (
+	"encoding/json/jsontext"
+	jsonv2 "encoding/json/v2"
+)
+
+// The jsonv2 experiment returns errors of UnmarshalJSON methods unchanged, thus without the struct field the
+// value belongs to. The decoder passed to UnmarshalJSONFrom knows the position of the value, and encoding/json
+// converts a *jsonv2.SemanticError at that position into a *json.UnmarshalTypeError with field context.
+
+// UnmarshalJSONFrom implements jsonv2.UnmarshalerFrom like UnmarshalJSON, but reports errors with the position
+// of the value. With encoding/json, they result in a *json.UnmarshalTypeError whose Err is the cause.
+func (d *Duration) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalJSONFrom(dec, d.decodeJSON)
+}
+
+// UnmarshalJSONFrom implements jsonv2.UnmarshalerFrom like UnmarshalJSON, see Duration.UnmarshalJSONFrom.
+func (d *LenientDuration) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalJSONFrom(dec, d.decodeJSON)
+}
+
+// UnmarshalJSONFrom implements jsonv2.UnmarshalerFrom like UnmarshalJSON, see Duration.UnmarshalJSONFrom.
+func (n *NullDuration) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	return unmarshalJSONFrom(dec, n.decodeJSON)
+}
+
+// unmarshalJSONFrom reads the next value from dec and decodes it with decode.
+// Errors are reported as *jsonv2.SemanticError at the position of the value.
+func unmarshalJSONFrom(dec *jsontext.Decoder, decode func(data []byte) *jsonDecodeError) error {
+	value, err := dec.ReadValue()
+	if err != nil {
+		return err
+	}
+
+	decodeErr := decode(value)
+	if decodeErr == nil {
+		return nil
+	}
+
+	out := &jsonv2.SemanticError{
+		ByteOffset:  dec.InputOffset() - int64(len(value)),
+		JSONPointer: dec.StackPointer(),
+		JSONKind:    value.Kind(),
+		GoType:      decodeErr.typ,
+		Err:         decodeErr.cause,
+	}
+	if out.JSONKind == '"' || out.JSONKind == '0' {
+		out.JSONValue = value.Clone()
+	}
+
+	return out
+}
@@ -0,0 +1,110 @@
+package iso8601
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"reflect"
+)
+
+// NullDuration is a Duration that may be null, like sql.NullString.
+// It can be used as scan destination, query argument and JSON or text field for optional durations.
+type NullDuration struct {
+	Duration Duration
+	// Valid is true if Duration is not null.
+	Valid bool
+}
+
+// Scan implements sql.Scanner. NULL results in an invalid NullDuration,
+// any other value is scanned like Duration.Scan.
+func (n *NullDuration) Scan(src any) error {
+	if src == nil {
+		*n = NullDuration{}
+
+		return nil
+	}
+
+	if err := n.Duration.Scan(src); err != nil {
+		return err
+	}
+	n.Valid = true
+
+	return nil
+}
+
+// Value implements driver.Valuer. An invalid NullDuration results in NULL,
+// a valid one is encoded like Duration.Value.
+func (n NullDuration) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+
+	return n.Duration.Value()
+}
+
+// MarshalJSON implements json.Marshaler. An invalid NullDuration is encoded as null,
+// a valid one as ISO 8601 string like Duration.MarshalJSON.
+func (n NullDuration) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return bytes.Clone(jsonNull), nil
+	}
+
+	return n.Duration.MarshalJSON()
+}
+
+// UnmarshalJSON implements json.Unmarshaler. null results in an invalid NullDuration,
+// an ISO 8601 string is decoded using DurationFromString.
+func (n *NullDuration) UnmarshalJSON(data []byte) error {
+	if err := n.decodeJSON(data); err != nil {
+		return err.typeError()
+	}
+
+	return nil
+}
+
+// decodeJSON decodes the JSON value data like UnmarshalJSON.
+func (n *NullDuration) decodeJSON(data []byte) *jsonDecodeError {
+	if bytes.Equal(data, jsonNull) {
+		*n = NullDuration{}
+
+		return nil
+	}
+
+	if len(data) == 0 || data[0] != '"' {
+		return &jsonDecodeError{data: data, typ: reflect.TypeFor[NullDuration]()}
+	}
+
+	if err := n.Duration.decodeJSONString(data, reflect.TypeFor[NullDuration]()); err != nil {
+		return err
+	}
+	n.Valid = true
+
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler. An invalid NullDuration is encoded as empty text,
+// a valid one as ISO 8601 string.
+func (n NullDuration) MarshalText() ([]byte, error) {
+	if !n.Valid {
+		return []byte{}, nil
+	}
+
+	return []byte(n.Duration.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Empty text results in an invalid NullDuration,
+// any other text is decoded using DurationFromString.
+func (n *NullDuration) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*n = NullDuration{}
+
+		return nil
+	}
+
+	out, err := DurationFromString(string(text))
+	if err != nil {
+		return err
+	}
+	*n = NullDuration{Duration: out, Valid: true}
+
+	return nil
+}
@@ -0,0 +1,149 @@
+package iso8601_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"github.com/Achsion/iso8601/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+var (
+	_ sql.Scanner              = (*iso8601.NullDuration)(nil)
+	_ driver.Valuer            = iso8601.NullDuration{}
+	_ json.Marshaler           = iso8601.NullDuration{}
+	_ json.Unmarshaler         = (*iso8601.NullDuration)(nil)
+	_ encoding.TextMarshaler   = iso8601.NullDuration{}
+	_ encoding.TextUnmarshaler = (*iso8601.NullDuration)(nil)
+)
+
+func mustNullDuration(t *testing.T, input string) iso8601.NullDuration {
+	t.Helper()
+
+	return iso8601.NullDuration{Duration: mustDurationFromString(t, input), Valid: true}
+}
+
+func TestNullDuration_Scan(t *testing.T) {
+	testCases := []struct {
+		name     string
+		src      any
+		expected iso8601.NullDuration
+	}{
+		{name: "null", src: nil, expected: iso8601.NullDuration{}},
+		{name: "iso", src: "P1DT2H", expected: mustNullDuration(t, "P1DT2H")},
+		{name: "postgres", src: []byte("-1 days -02:00:00"), expected: mustNullDuration(t, "-P1DT2H")},
+		{name: "zero", src: "PT0S", expected: mustNullDuration(t, "PT0S")},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := mustNullDuration(t, "P5D")
+			require.NoError(t, actual.Scan(tc.src))
+
+			assert.Equal(t, tc.expected.Valid, actual.Valid)
+			assert.True(t, tc.expected.Duration.StrictEqual(actual.Duration), actual.Duration.String())
+		})
+	}
+}
+
+func TestNullDuration_Scan_Error(t *testing.T) {
+	actual := iso8601.NullDuration{}
+	assert.Error(t, actual.Scan("P1X"))
+	assert.False(t, actual.Valid)
+
+	assert.ErrorIs(t, actual.Scan("1 mon -1 days"), iso8601.ErrMixedSigns)
+	assert.Error(t, actual.Scan(int64(5)))
+}
+
+func TestNullDuration_Value(t *testing.T) {
+	actual, err := iso8601.NullDuration{}.Value()
+	require.NoError(t, err)
+	assert.Nil(t, actual)
+
+	actual, err = mustNullDuration(t, "-P1DT2H").Value()
+	require.NoError(t, err)
+	assert.Equal(t, "P-1DT-2H", actual)
+}
+
+type jsonOptionalConfig struct {
+	Timeout iso8601.NullDuration `json:"timeout"`
+	Backoff iso8601.NullDuration `json:"backoff"`
+}
+
+func TestNullDuration_MarshalJSON(t *testing.T) {
+	config := jsonOptionalConfig{Timeout: mustNullDuration(t, "PT30S")}
+
+	actual, err := json.Marshal(config)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"timeout":"PT30S","backoff":null}`, string(actual))
+}
+
+func TestNullDuration_UnmarshalJSON(t *testing.T) {
+	config := jsonOptionalConfig{Backoff: mustNullDuration(t, "PT1S")}
+	err := json.Unmarshal([]byte(`{"timeout":"-PT0.5S","backoff":null}`), &config)
+	require.NoError(t, err)
+
+	assert.True(t, config.Timeout.Valid)
+	assert.Equal(t, "-PT0.5S", config.Timeout.Duration.String())
+	assert.Equal(t, iso8601.NullDuration{}, config.Backoff)
+}
+
+func TestNullDuration_UnmarshalJSON_Error(t *testing.T) {
+	for _, input := range []string{`"P1X"`, `90`, `true`, `""`} {
+		t.Run(input, func(t *testing.T) {
+			var actual iso8601.NullDuration
+			err := actual.UnmarshalJSON([]byte(input))
+
+			var typeErr *json.UnmarshalTypeError
+			require.ErrorAs(t, err, &typeErr)
+			assert.False(t, actual.Valid)
+		})
+	}
+}
+
+func TestNullDuration_UnmarshalJSON_ErrorFieldContext(t *testing.T) {
+	var config jsonOptionalConfig
+	err := json.Unmarshal([]byte(`{"timeout":null,"backoff":"PT1X"}`), &config)
+
+	var typeErr *json.UnmarshalTypeError
+	require.ErrorAs(t, err, &typeErr)
+	assert.Equal(t, "backoff", typeErr.Field)
+	assert.Contains(t, err.Error(), "jsonOptionalConfig.backoff of type iso8601.NullDuration")
+}
+
+func TestNullDuration_MarshalText_RoundTrip(t *testing.T) {
+	testCases := []struct {
+		input    iso8601.NullDuration
+		expected string
+	}{
+		{input: iso8601.NullDuration{}, expected: ""},
+		{input: mustNullDuration(t, "P1Y2M3W4DT5H6M7.5S"), expected: "P1Y2M3W4DT5H6M7.5S"},
+		{input: mustNullDuration(t, "-PT1S"), expected: "-PT1S"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expected, func(t *testing.T) {
+			text, err := tc.input.MarshalText()
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, string(text))
+
+			actual := mustNullDuration(t, "P5D")
+			require.NoError(t, actual.UnmarshalText(text))
+			assert.Equal(t, tc.input.Valid, actual.Valid)
+			assert.True(t, tc.input.Duration.StrictEqual(actual.Duration))
+		})
+	}
+}
+
+func TestNullDuration_UnmarshalText_Error(t *testing.T) {
+	var actual iso8601.NullDuration
+	err := actual.UnmarshalText([]byte("P1X"))
+
+	var parseErr *iso8601.ParseError
+	require.ErrorAs(t, err, &parseErr)
+	assert.Equal(t, iso8601.ReasonInvalidDesignator, parseErr.Reason)
+	assert.False(t, actual.Valid)
+}
@@ -0,0 +1,62 @@
+package iso8601
+
+import "time"
+
+// ParseOrdinalDate parses an ISO 8601 ordinal date in the extended format "YYYY-DDD" or the basic format "YYYYDDD",
+// e.g. "2024-075", into a time.Time at midnight UTC. Day 366 is only valid in leap years.
+func ParseOrdinalDate(ordinalDateString string) (time.Time, error) {
+	scanner := dateTimeScanner{input: ordinalDateString}
+
+	year, day, err := scanner.scanOrdinalDate()
+	if err != nil {
+		return time.Time{}, err
+	}
+	if err := scanner.end(); err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Date(year, time.January, day, 0, 0, 0, 0, time.UTC), nil
+}
+
+// FormatOrdinalDate returns the ISO 8601 ordinal date of t, e.g. "2024-075" or "2024075".
+func FormatOrdinalDate(t time.Time, style FormatStyle) string {
+	var arr [32]byte
+
+	buf := appendYear(arr[:0], t.Year())
+	if style == FormatExtended {
+		buf = append(buf, dateSeparator)
+	}
+
+	return string(appendInt(buf, t.YearDay(), 3))
+}
+
+// daysInYear returns the number of days of year, either 365 or 366.
+func daysInYear(year int) int {
+	if isLeapYear(year) {
+		return 366
+	}
+
+	return 365
+}
+
+// scanOrdinalDate scans an ordinal date "YYYY-DDD" or "YYYYDDD".
+func (s *dateTimeScanner) scanOrdinalDate() (year, day int, err error) {
+	year, err = s.digits(4)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if err := s.separator(dateSeparator); err != nil {
+		return 0, 0, err
+	}
+	dayOffset := s.pos
+	day, err = s.digits(3)
+	if err != nil {
+		return 0, 0, err
+	}
+	if day < 1 || day > daysInYear(year) {
+		return 0, 0, newParseError(s.input, dayOffset, ReasonFieldRange)
+	}
+
+	return year, day, nil
+}
@@ -0,0 +1,102 @@
+package iso8601_test
+
+import (
+	"github.com/Achsion/iso8601/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestParseOrdinalDate(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected time.Time
+	}{
+		{input: "2024-075", expected: time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)},
+		{input: "2024075", expected: time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)},
+		{input: "2023-075", expected: time.Date(2023, 3, 16, 0, 0, 0, 0, time.UTC)},
+		{input: "2024-001", expected: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{input: "2024-366", expected: time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)},
+		{input: "2000366", expected: time.Date(2000, 12, 31, 0, 0, 0, 0, time.UTC)},
+		{input: "2023-365", expected: time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			actual, err := iso8601.ParseOrdinalDate(tc.input)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestParseOrdinalDate_Error(t *testing.T) {
+	testCases := []struct {
+		name           string
+		input          string
+		expectedOffset int
+		expectedReason iso8601.ParseErrorReason
+	}{
+		{"day zero", "2024-000", 5, iso8601.ReasonFieldRange},
+		{"day 366 in non-leap year", "2023-366", 5, iso8601.ReasonFieldRange},
+		{"day 366 in century", "1900366", 4, iso8601.ReasonFieldRange},
+		{"day 367", "2024-367", 5, iso8601.ReasonFieldRange},
+		{"short day", "2024-75", 7, iso8601.ReasonMissingDigits},
+		{"calendar date", "2024-03-15", 7, iso8601.ReasonMissingDigits},
+		{"basic calendar date", "20240315", 7, iso8601.ReasonInvalidDesignator},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := iso8601.ParseOrdinalDate(tc.input)
+
+			var parseErr *iso8601.ParseError
+			require.ErrorAs(t, err, &parseErr)
+			assert.Equal(t, tc.expectedOffset, parseErr.Offset)
+			assert.Equal(t, tc.expectedReason, parseErr.Reason)
+		})
+	}
+}
+
+func TestFormatOrdinalDate(t *testing.T) {
+	testCases := []struct {
+		stdTime       time.Time
+		expected      string
+		expectedBasic string
+	}{
+		{time.Date(2024, 3, 15, 14, 30, 0, 0, time.UTC), "2024-075", "2024075"},
+		{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), "2024-001", "2024001"},
+		{time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC), "2024-366", "2024366"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expected, func(t *testing.T) {
+			assert.Equal(t, tc.expected, iso8601.FormatOrdinalDate(tc.stdTime, iso8601.FormatExtended))
+			assert.Equal(t, tc.expectedBasic, iso8601.FormatOrdinalDate(tc.stdTime, iso8601.FormatBasic))
+		})
+	}
+}
+
+func TestOrdinalDate_AddToTime(t *testing.T) {
+	testCases := []struct {
+		input    string
+		dur      string
+		expected string
+	}{
+		{input: "2024-075", dur: "P30D", expected: "2024-105"},
+		{input: "2024-350", dur: "P30D", expected: "2025-014"},
+		{input: "2024-075", dur: "-P75D", expected: "2023-365"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input+"+"+tc.dur, func(t *testing.T) {
+			stdTime, err := iso8601.ParseOrdinalDate(tc.input)
+			require.NoError(t, err)
+
+			actual, err := mustDurationFromString(t, tc.dur).AddToTime(stdTime)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, iso8601.FormatOrdinalDate(actual, iso8601.FormatExtended))
+		})
+	}
+}
@@ -1,172 +1,259 @@
 package iso8601
 
 import (
-	"fmt"
-	"strconv"
+	"math"
+	"math/bits"
 	"time"
-	"unicode"
 )
 
 // time values for missing time values
 const (
 	TimeDay   = 24 * time.Hour
+	TimeWeek  = 7 * TimeDay
 	TimeMonth = 30 * TimeDay
 	TimeYear  = 365 * TimeDay
 )
 
+// average lengths of the gregorian calendar, as an alternative to TimeYear and TimeMonth
 const (
-	durationStringShift = 1
-	idxLookupShift      = 1
+	TimeGregorianYear  = 365*TimeDay + 5*time.Hour + 49*time.Minute + 12*time.Second // 365.2425 days
+	TimeGregorianMonth = TimeGregorianYear / 12                                      // 30.436875 days
 )
 
+// CalendarUnitPolicy defines how a Parser handles the calendar units years and months,
+// as they do not have a fixed length.
+type CalendarUnitPolicy int
+
 const (
-	yearIdx = iota + 1
-	monthIdx
-	dayIdx
-	hourIdx
-	minuteIdx
-	secondSepIdx
-	secondIdx
+	// CalendarUnitsApproximate converts years and months using the nominal lengths of the Parser.
+	CalendarUnitsApproximate CalendarUnitPolicy = iota
+	// CalendarUnitsReject rejects every duration string containing a year or month designator
+	// with a ParseError with ReasonCalendarUnit.
+	CalendarUnitsReject
+	// CalendarUnitsError accepts year and month designators, but returns ErrCalendarUnit
+	// if any of them has a value other than zero.
+	CalendarUnitsError
 )
 
-var dateLookup = map[int32]int{
-	yearDesignator:  yearIdx,
-	monthDesignator: monthIdx,
-	dayDesignator:   dayIdx,
+// Parser is a configurable version of ParseToDuration.
+// The zero value behaves exactly like ParseToDuration.
+type Parser struct {
+	// YearLength is the nominal length of a year. Defaults to TimeYear if not positive.
+	YearLength time.Duration
+	// MonthLength is the nominal length of a month. Defaults to TimeMonth if not positive.
+	MonthLength time.Duration
+	// WeekLength is the nominal length of a week. Defaults to TimeWeek if not positive.
+	WeekLength time.Duration
+	// DayLength is the nominal length of a day. Defaults to TimeDay if not positive.
+	DayLength time.Duration
+
+	// CalendarUnits defines how years and months are handled.
+	CalendarUnits CalendarUnitPolicy
+	// ClampOverflow clamps durations exceeding the time.Duration range to math.MaxInt64 or math.MinInt64
+	// instead of returning an error.
+	ClampOverflow bool
 }
-var timeLookup = map[int32]int{
-	hourDesignator:        hourIdx,
-	minuteDesignator:      minuteIdx,
-	secondCommaDesignator: secondSepIdx,
-	secondDesignator:      secondIdx,
+
+// maxFractionDigits is the maximum number of fraction digits that are taken into account,
+// any further digit is ignored. 10^18 still fits into an uint64.
+const maxFractionDigits = 18
+
+// unitDurations stores the default time.Duration of each durationUnit
+var unitDurations = [...]time.Duration{
+	yearUnit:   TimeYear,
+	monthUnit:  TimeMonth,
+	weekUnit:   TimeWeek,
+	dayUnit:    TimeDay,
+	hourUnit:   time.Hour,
+	minuteUnit: time.Minute,
+	secondUnit: time.Second,
 }
 
-// decimalPointMultiplier stores the pre-computed multiplier used for decimal points calculation
-var decimalPointMultiplier = [...]time.Duration{
-	1e09, 1e08, 1e07, 1e06, 1e05, 1e04, 1e03, 1e02, 1e01, 1e00,
+// unitDurations returns the time.Duration of each durationUnit, taking the nominal lengths of p into account.
+func (p Parser) unitDurations() [len(unitDurations)]time.Duration {
+	out := unitDurations
+
+	if p.YearLength > 0 {
+		out[yearUnit] = p.YearLength
+	}
+	if p.MonthLength > 0 {
+		out[monthUnit] = p.MonthLength
+	}
+	if p.WeekLength > 0 {
+		out[weekUnit] = p.WeekLength
+	}
+	if p.DayLength > 0 {
+		out[dayUnit] = p.DayLength
+	}
+
+	return out
 }
 
-var invalidFormatErr = fmt.Errorf("invalid iso8601 duration format")
+// pow10 stores the pre-computed powers of ten used for decimal fraction calculation
+var pow10 = [...]uint64{
+	1e00, 1e01, 1e02, 1e03, 1e04, 1e05, 1e06, 1e07, 1e08, 1e09,
+	1e10, 1e11, 1e12, 1e13, 1e14, 1e15, 1e16, 1e17, 1e18,
+}
 
 // ParseToDuration is a fast func that parses an ISO 8601 duration string into a time.Duration.
 // It accepts negative durations but only by prepending a '-' like: "[-]P<duration>".
+// The lowest-order component may contain a decimal fraction, separated by either a
+// full stop or a comma, e.g. "PT1.5H" or "PT1,5H".
 //
-// It is very inaccurate for parsing durations with larger parts than a day and does not support weeks.
+// It is very inaccurate for parsing durations with larger parts than a day, as it assumes
+// every year to be TimeYear, every month to be TimeMonth, every week to be TimeWeek and every day to be TimeDay.
 // Use DurationFromString if you need to handle those.
+//
+// Durations that exceed the time.Duration range result in a ParseError with ReasonOverflow.
+// Use ParseToDurationClamped to clamp them instead.
 func ParseToDuration(durationString string) (time.Duration, error) {
-	isNegative := false
-
-	// consume [-]?
-	if durationString != "" {
-		firstChar := durationString[0]
-		if firstChar == '-' {
-			isNegative = true
-			durationString = durationString[1:]
-		}
-	}
+	return Parser{}.ParseToDuration(durationString)
+}
 
-	if len(durationString) < 3 || durationString[0] != startDesignator {
-		// duration string has to start with 'P' or '-P' and the shortest possible length is 3 (e.g. "P3D")
-		return 0, invalidFormatErr
+// ParseToDurationClamped works like ParseToDuration, but clamps durations that exceed the time.Duration range
+// to math.MaxInt64 or math.MinInt64 instead of returning an error.
+func ParseToDurationClamped(durationString string) (time.Duration, error) {
+	return Parser{ClampOverflow: true}.ParseToDuration(durationString)
+}
+
+// ParseToDuration parses an ISO 8601 duration string into a time.Duration like the package level ParseToDuration,
+// but uses the nominal unit lengths and policies of p.
+func (p Parser) ParseToDuration(durationString string) (time.Duration, error) {
+	scanner, err := newDurationScanner(durationString)
+	if err != nil {
+		return 0, err
 	}
+	isNegative := scanner.isNegative
 
-	var stringPart string
-	var idx int
-	lastIdx := -1
-	interpretDate := true
-
-	// duration string split in its parts
-	durationParts := make([]string, 7)
-
-	// separating duration string into parts
-	numberStartIndex := 0
-	for charIndex, nextChar := range durationString[durationStringShift:] {
-		if nextChar == timeSwitchDesignator {
-			interpretDate = false
-			numberStartIndex = charIndex + 1
-			continue
-		}
+	sum := newDurationSum(isNegative)
+	hasCalendarValue := false
+	durations := p.unitDurations()
 
-		if !unicode.IsNumber(nextChar) {
-			stringPart = durationString[numberStartIndex+durationStringShift : charIndex+durationStringShift]
+	for {
+		component, ok, err := scanner.next()
+		if err != nil {
+			return 0, err
+		}
+		if !ok {
+			break
+		}
 
-			if interpretDate {
-				idx = dateLookup[nextChar]
-			} else {
-				idx = timeLookup[nextChar]
+		if isCalendarUnit(component.unit) {
+			if p.CalendarUnits == CalendarUnitsReject {
+				return 0, newParseError(durationString, component.designatorOffset, ReasonCalendarUnit)
 			}
-
-			if idx == 0 || lastIdx >= idx {
-				// the designators are in the wrong order / there is a duplicate designator
-				return 0, invalidFormatErr
+			if p.CalendarUnits == CalendarUnitsError {
+				hasCalendarValue = hasCalendarValue || component.value != 0 || component.fraction != 0
 			}
+		}
 
-			durationParts[idx-idxLookupShift] = stringPart
-			numberStartIndex = charIndex + 1
-			lastIdx = idx
+		if component.isValueOverflow ||
+			!sum.add(component.value, component.fraction, component.fractionDigits, durations[component.unit]) {
+			if !p.ClampOverflow {
+				return 0, newParseError(durationString, component.valueOffset, ReasonOverflow)
+			}
+			// keep on parsing to validate the rest of the duration string
+			sum.isOverflow = true
 		}
 	}
 
-	if numberStartIndex+1 < len(durationString) {
-		// there are still some characters 'left' in the string that should not be there
-		return 0, invalidFormatErr
+	if hasCalendarValue {
+		return 0, ErrCalendarUnit
 	}
 
-	return calculateDuration(durationParts, isNegative), nil
+	return sum.duration(), nil
 }
 
-func calculateDuration(durationParts []string, isNegative bool) time.Duration {
-	var resultDur time.Duration
-	var nrVal int
+// isCalendarUnit reports whether unit has no fixed length.
+func isCalendarUnit(unit durationUnit) bool {
+	return unit == yearUnit || unit == monthUnit
+}
 
-	if durationParts[yearIdx-idxLookupShift] != "" {
-		nrVal, _ = strconv.Atoi(durationParts[yearIdx-idxLookupShift])
-		resultDur += TimeYear * time.Duration(nrVal)
-	}
-	if durationParts[monthIdx-idxLookupShift] != "" {
-		nrVal, _ = strconv.Atoi(durationParts[monthIdx-idxLookupShift])
-		resultDur += TimeMonth * time.Duration(nrVal)
+// appendDigit appends the decimal digit to value. It reports an overflow if the result does not fit into an uint64
+// or if an overflow has already been reported before.
+func appendDigit(value uint64, digit byte, isOverflow bool) (uint64, bool) {
+	digitVal := uint64(digit - '0')
+	if isOverflow || value > (math.MaxUint64-digitVal)/10 {
+		return value, true
 	}
-	if durationParts[dayIdx-idxLookupShift] != "" {
-		nrVal, _ = strconv.Atoi(durationParts[dayIdx-idxLookupShift])
-		resultDur += TimeDay * time.Duration(nrVal)
+
+	return value*10 + digitVal, false
+}
+
+// durationSum sums up the absolute durations of components of the same sign into a time.Duration.
+// The absolute duration is summed up, as the negative range is larger by one than the positive range.
+type durationSum struct {
+	total      uint64
+	max        uint64
+	isNegative bool
+	// isOverflow clamps the sum to math.MinInt64 or math.MaxInt64. It is set by the caller after an overflow.
+	isOverflow bool
+}
+
+// newDurationSum returns an empty durationSum of the given sign.
+func newDurationSum(isNegative bool) durationSum {
+	out := durationSum{max: math.MaxInt64, isNegative: isNegative}
+	if isNegative {
+		out.max++
 	}
-	if durationParts[hourIdx-idxLookupShift] != "" {
-		nrVal, _ = strconv.Atoi(durationParts[hourIdx-idxLookupShift])
-		resultDur += time.Hour * time.Duration(nrVal)
+
+	return out
+}
+
+// add adds the absolute duration of the component value.fraction in the unit unitDur.
+// It reports false and leaves the sum unchanged if the sum would exceed the time.Duration range.
+func (s *durationSum) add(value, fraction uint64, fractionDigits int, unitDur time.Duration) bool {
+	componentDur, ok := calculateComponentDuration(value, fraction, fractionDigits, unitDur)
+	if !ok || componentDur > s.max-s.total {
+		return false
 	}
-	if durationParts[minuteIdx-idxLookupShift] != "" {
-		nrVal, _ = strconv.Atoi(durationParts[minuteIdx-idxLookupShift])
-		resultDur += time.Minute * time.Duration(nrVal)
+	s.total += componentDur
+
+	return true
+}
+
+// addDecimal works like add for a component stored as decimal.
+func (s *durationSum) addDecimal(value decimal, unitDur time.Duration) bool {
+	return s.add(value.whole, uint64(value.fraction), decimalPrecision, unitDur)
+}
+
+// duration returns the signed sum, which is clamped if an overflow has been reported.
+func (s durationSum) duration() time.Duration {
+	total := s.total
+	if s.isOverflow {
+		total = s.max
 	}
-	if durationParts[secondIdx-idxLookupShift] != "" {
-		decimalSecsStr := durationParts[secondIdx-idxLookupShift]
-		decimalPoints := len(decimalSecsStr)
-		if decimalPoints > 9 {
-			decimalSecsStr = decimalSecsStr[:9]
-			decimalPoints = 9
-		}
-		nrVal, _ = strconv.Atoi(decimalSecsStr)
 
-		if durationParts[secondSepIdx-idxLookupShift] != "" {
-			sVal, _ := strconv.Atoi(durationParts[secondSepIdx-idxLookupShift])
-			resultDur += time.Second*time.Duration(sVal) + calculateDecimalSecondsDuration(nrVal, decimalPoints)
-		} else {
-			resultDur += time.Second * time.Duration(nrVal)
-		}
+	if s.isNegative {
+		// also correct for the absolute value of math.MinInt64, as the conversion wraps around
+		return -time.Duration(total)
 	}
 
-	if isNegative {
-		resultDur = -resultDur
+	return time.Duration(total)
+}
+
+// calculateComponentDuration returns the absolute duration of the component value.fraction in the unit unitDur.
+// It reports false if the result does not fit into an uint64.
+func calculateComponentDuration(value, fraction uint64, fractionDigits int, unitDur time.Duration) (uint64, bool) {
+	hi, wholeDur := bits.Mul64(value, uint64(unitDur))
+	if hi != 0 {
+		return 0, false
 	}
 
-	return resultDur
+	out, carry := bits.Add64(wholeDur, uint64(calculateFractionDuration(fraction, fractionDigits, unitDur)), 0)
+
+	return out, carry == 0
 }
 
-func calculateDecimalSecondsDuration(decimalSeconds int, multiplyIdx int) time.Duration {
-	if decimalSeconds == 0 {
+// calculateFractionDuration returns the truncated duration of fraction/10**fractionDigits units of unitDur.
+func calculateFractionDuration(fraction uint64, fractionDigits int, unitDur time.Duration) time.Duration {
+	if fraction == 0 {
 		return 0
 	}
 
-	return time.Duration(decimalSeconds) * decimalPointMultiplier[multiplyIdx]
+	// fraction < 10**fractionDigits, thus the quotient is always smaller than unitDur and cannot overflow
+	hi, lo := bits.Mul64(fraction, uint64(unitDur))
+	quotient, _ := bits.Div64(hi, lo, pow10[fractionDigits])
+
+	return time.Duration(quotient)
 }
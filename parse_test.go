@@ -4,6 +4,7 @@ import (
 	"github.com/Achsion/iso8601/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"math"
 	"testing"
 	"time"
 )
@@ -89,6 +90,58 @@ func TestParseToDuration(t *testing.T) {
 			isoStr:   "P7Y6DT5M",
 			expected: 7*iso8601.TimeYear + 6*iso8601.TimeDay + 5*time.Minute,
 		},
+		{
+			isoStr:   "P2W",
+			expected: 2 * iso8601.TimeWeek,
+		},
+		{
+			isoStr:   "P1Y2M3W4D",
+			expected: 1*iso8601.TimeYear + 2*iso8601.TimeMonth + 3*iso8601.TimeWeek + 4*iso8601.TimeDay,
+		},
+		{
+			isoStr:   "P0.5Y",
+			expected: iso8601.TimeYear / 2,
+		},
+		{
+			isoStr:   "P1.5M",
+			expected: 45 * iso8601.TimeDay,
+		},
+		{
+			isoStr:   "P1.5W",
+			expected: 10*iso8601.TimeDay + 12*time.Hour,
+		},
+		{
+			isoStr:   "P1.25D",
+			expected: 30 * time.Hour,
+		},
+		{
+			isoStr:   "PT1.5H",
+			expected: 90 * time.Minute,
+		},
+		{
+			isoStr:   "P1DT2.75H",
+			expected: iso8601.TimeDay + 2*time.Hour + 45*time.Minute,
+		},
+		{
+			isoStr:   "PT0.1M",
+			expected: 6 * time.Second,
+		},
+		{
+			isoStr:   "PT1.000000000000000001H", // digits beyond the 18th fraction digit are ignored
+			expected: 1 * time.Hour,
+		},
+		{
+			isoStr:   "-P1.5D",
+			expected: -36 * time.Hour,
+		},
+		{
+			isoStr:   "PT1,5S", // decimal comma, as preferred by ISO 8601
+			expected: 1500 * time.Millisecond,
+		},
+		{
+			isoStr:   "PT2H1,25M",
+			expected: 2*time.Hour + 75*time.Second,
+		},
 	}
 
 	for _, test := range testCases {
@@ -149,6 +202,26 @@ func TestParseToDurationError(t *testing.T) {
 			name:   "double designator",
 			isoStr: "P1Y2M3DT4H3H5M6S",
 		},
+		{
+			name:   "fraction on a component that is not the lowest-order one",
+			isoStr: "P1.5DT2H",
+		},
+		{
+			name:   "multiple decimal points",
+			isoStr: "PT1.2.3S",
+		},
+		{
+			name:   "decimal comma and decimal point",
+			isoStr: "PT1,2.3S",
+		},
+		{
+			name:   "week designator in time section",
+			isoStr: "PT1W",
+		},
+		{
+			name:   "non-ASCII digit",
+			isoStr: "P٣D",
+		},
 	}
 
 	for _, test := range testCases {
@@ -160,6 +233,213 @@ func TestParseToDurationError(t *testing.T) {
 	}
 }
 
+func TestParseToDuration_Overflow(t *testing.T) {
+	testCases := []struct {
+		name           string
+		isoStr         string
+		expectedOffset int
+	}{
+		{
+			name:           "too many years",
+			isoStr:         "P999999999Y",
+			expectedOffset: 1,
+		},
+		{
+			name:           "too many digits",
+			isoStr:         "PT99999999999999999999999S",
+			expectedOffset: 2,
+		},
+		{
+			name:           "sum of components",
+			isoStr:         "P292Y1000D",
+			expectedOffset: 5,
+		},
+		{
+			name:           "max duration plus one nanosecond",
+			isoStr:         "PT2562047H47M16.854775808S",
+			expectedOffset: 13,
+		},
+		{
+			name:           "min duration minus one nanosecond",
+			isoStr:         "-PT2562047H47M16.854775809S",
+			expectedOffset: 14,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := iso8601.ParseToDuration(tc.isoStr)
+			require.ErrorIs(t, err, iso8601.ErrOverflow)
+
+			var parseErr *iso8601.ParseError
+			require.ErrorAs(t, err, &parseErr)
+			assert.Equal(t, iso8601.ReasonOverflow, parseErr.Reason)
+			assert.Equal(t, tc.expectedOffset, parseErr.Offset)
+		})
+	}
+}
+
+func TestParseToDuration_Bounds(t *testing.T) {
+	t.Run("max duration", func(t *testing.T) {
+		dur, err := iso8601.ParseToDuration("PT2562047H47M16.854775807S")
+		require.NoError(t, err)
+		assert.Equal(t, time.Duration(math.MaxInt64), dur)
+	})
+	t.Run("min duration", func(t *testing.T) {
+		dur, err := iso8601.ParseToDuration("-PT2562047H47M16.854775808S")
+		require.NoError(t, err)
+		assert.Equal(t, time.Duration(math.MinInt64), dur)
+	})
+}
+
+func TestParseToDurationClamped(t *testing.T) {
+	testCases := []struct {
+		isoStr   string
+		expected time.Duration
+	}{
+		{
+			isoStr:   "P999999999Y",
+			expected: math.MaxInt64,
+		},
+		{
+			isoStr:   "-P999999999Y",
+			expected: math.MinInt64,
+		},
+		{
+			isoStr:   "PT99999999999999999999999S",
+			expected: math.MaxInt64,
+		},
+		{
+			isoStr:   "P292Y1000DT1H",
+			expected: math.MaxInt64,
+		},
+		{
+			isoStr:   "PT1H30M",
+			expected: 90 * time.Minute,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.isoStr, func(t *testing.T) {
+			dur, err := iso8601.ParseToDurationClamped(tc.isoStr)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, dur)
+		})
+	}
+
+	t.Run("invalid format after overflow", func(t *testing.T) {
+		_, err := iso8601.ParseToDurationClamped("P999999999Y1G")
+
+		var parseErr *iso8601.ParseError
+		require.ErrorAs(t, err, &parseErr)
+		assert.Equal(t, iso8601.ReasonInvalidDesignator, parseErr.Reason)
+	})
+}
+
+func TestParser_ParseToDuration(t *testing.T) {
+	testCases := []struct {
+		name     string
+		parser   iso8601.Parser
+		isoStr   string
+		expected time.Duration
+	}{
+		{
+			name:     "defaults",
+			parser:   iso8601.Parser{},
+			isoStr:   "P1Y2M3W4DT5H",
+			expected: iso8601.TimeYear + 2*iso8601.TimeMonth + 3*iso8601.TimeWeek + 4*iso8601.TimeDay + 5*time.Hour,
+		},
+		{
+			name:     "gregorian year",
+			parser:   iso8601.Parser{YearLength: iso8601.TimeGregorianYear},
+			isoStr:   "P1Y",
+			expected: 365*iso8601.TimeDay + 5*time.Hour + 49*time.Minute + 12*time.Second,
+		},
+		{
+			name:     "gregorian month",
+			parser:   iso8601.Parser{MonthLength: iso8601.TimeGregorianMonth},
+			isoStr:   "P1M",
+			expected: 30*iso8601.TimeDay + 10*time.Hour + 29*time.Minute + 6*time.Second,
+		},
+		{
+			name:     "custom week and day length",
+			parser:   iso8601.Parser{WeekLength: 5 * iso8601.TimeDay, DayLength: 8 * time.Hour},
+			isoStr:   "P2W1.5D",
+			expected: 10*iso8601.TimeDay + 12*time.Hour,
+		},
+		{
+			name:     "negative lengths use defaults",
+			parser:   iso8601.Parser{YearLength: -1, MonthLength: -1, WeekLength: -1, DayLength: -1},
+			isoStr:   "P1Y1M1W1D",
+			expected: iso8601.TimeYear + iso8601.TimeMonth + iso8601.TimeWeek + iso8601.TimeDay,
+		},
+		{
+			name:     "calendar units with zero values",
+			parser:   iso8601.Parser{CalendarUnits: iso8601.CalendarUnitsError},
+			isoStr:   "P0Y0M3DT1H",
+			expected: 3*iso8601.TimeDay + time.Hour,
+		},
+		{
+			name:     "calendar units rejected, but not present",
+			parser:   iso8601.Parser{CalendarUnits: iso8601.CalendarUnitsReject},
+			isoStr:   "P3DT1M",
+			expected: 3*iso8601.TimeDay + time.Minute,
+		},
+		{
+			name:     "clamped overflow",
+			parser:   iso8601.Parser{ClampOverflow: true, YearLength: iso8601.TimeGregorianYear},
+			isoStr:   "-P300Y",
+			expected: math.MinInt64,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dur, err := tc.parser.ParseToDuration(tc.isoStr)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, dur)
+		})
+	}
+}
+
+func TestParser_ParseToDuration_CalendarUnits(t *testing.T) {
+	t.Run("reject year", func(t *testing.T) {
+		_, err := iso8601.Parser{CalendarUnits: iso8601.CalendarUnitsReject}.ParseToDuration("P0Y3D")
+		require.ErrorIs(t, err, iso8601.ErrCalendarUnit)
+
+		var parseErr *iso8601.ParseError
+		require.ErrorAs(t, err, &parseErr)
+		assert.Equal(t, iso8601.ReasonCalendarUnit, parseErr.Reason)
+		assert.Equal(t, 2, parseErr.Offset)
+	})
+	t.Run("reject month", func(t *testing.T) {
+		_, err := iso8601.Parser{CalendarUnits: iso8601.CalendarUnitsReject}.ParseToDuration("P1M")
+		require.ErrorIs(t, err, iso8601.ErrCalendarUnit)
+	})
+	t.Run("error on non-zero year", func(t *testing.T) {
+		_, err := iso8601.Parser{CalendarUnits: iso8601.CalendarUnitsError}.ParseToDuration("P1Y")
+		require.ErrorIs(t, err, iso8601.ErrCalendarUnit)
+	})
+	t.Run("error on fractional month", func(t *testing.T) {
+		_, err := iso8601.Parser{CalendarUnits: iso8601.CalendarUnitsError}.ParseToDuration("P0.5M")
+		require.ErrorIs(t, err, iso8601.ErrCalendarUnit)
+	})
+	t.Run("format errors take precedence", func(t *testing.T) {
+		_, err := iso8601.Parser{CalendarUnits: iso8601.CalendarUnitsError}.ParseToDuration("P1Y1G")
+
+		var parseErr *iso8601.ParseError
+		require.ErrorAs(t, err, &parseErr)
+		assert.Equal(t, iso8601.ReasonInvalidDesignator, parseErr.Reason)
+	})
+}
+
+func TestParseToDuration_NoAllocations(t *testing.T) {
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = iso8601.ParseToDuration("-P1Y2M3W4DT5H6M7.89S")
+	})
+	assert.Zero(t, allocs)
+}
+
 func BenchmarkParse_StdDuration(b *testing.B) {
 	cases := []struct {
 		name      string
@@ -170,11 +450,14 @@ func BenchmarkParse_StdDuration(b *testing.B) {
 		{name: "3h40m", isoString: "PT3H40M"},
 		{name: "1h2m3.456s", isoString: "PT1H2M3.456S"},
 		{name: "one of everything", isoString: "P12Y32M153DT7H15M6.7023S"},
+		{name: "weeks", isoString: "P3W"},
+		{name: "fractional hours", isoString: "PT1.5H"},
 	}
 
 	b.ResetTimer()
 	for _, benchCase := range cases {
 		b.Run(benchCase.name, func(b *testing.B) {
+			b.ReportAllocs()
 			for i := 0; i < b.N; i++ {
 				_, _ = iso8601.ParseToDuration(benchCase.isoString)
 			}
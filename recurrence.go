@@ -0,0 +1,277 @@
+package iso8601
+
+import (
+	"errors"
+	"iter"
+	"math"
+	"math/bits"
+	"strconv"
+	"time"
+)
+
+// Recurrence is an ISO 8601 recurring time interval, e.g. "R5/2024-01-01T09:00Z/P1W" or the unbounded
+// "R/2024-01-01/PT15M". Its occurrences are the starts of the repeated intervals, each one step apart,
+// where the step is the duration of the interval.
+//
+// Every occurrence is calculated from the anchor of the interval by adding a multiple of the step with
+// MonthEndClamp, so that e.g. monthly occurrences starting on January 31 fall on the last day of shorter months,
+// like February 29, and do not drift to the 29th afterward.
+type Recurrence struct {
+	interval Interval
+	step     Duration
+	// count is the number of occurrences, or -1 for an unbounded recurrence
+	count int
+}
+
+// RecurrenceFromInterval creates a Recurrence that repeats interval count times, or without limit if count is negative.
+// The interval must have a start or an end and a positive duration. Unbounded recurrences require a start.
+// For an interval with an end but no start, the last repetition ends at the end of the interval.
+func RecurrenceFromInterval(interval Interval, count int) (Recurrence, error) {
+	out := Recurrence{interval: interval, step: interval.Duration(), count: max(count, -1)}
+	if err := out.validate(); err != nil {
+		return Recurrence{}, err
+	}
+
+	return out, nil
+}
+
+// ParseRecurrence parses an ISO 8601 recurring time interval "R[n]/<interval>", where n is the optional number of
+// occurrences and the interval follows the rules of ParseInterval, e.g. "R5/2024-01-01T09:00Z/P1W".
+// The interval must satisfy the requirements of RecurrenceFromInterval.
+func ParseRecurrence(recurrenceString string) (Recurrence, error) {
+	if len(recurrenceString) == 0 || recurrenceString[0] != recurrenceDesignator {
+		return Recurrence{}, newParseError(recurrenceString, 0, ReasonInvalidDesignator)
+	}
+	pos := 1
+
+	count := -1
+	if pos < len(recurrenceString) && isDigit(recurrenceString[pos]) {
+		var value uint64
+		isOverflow := false
+		for pos < len(recurrenceString) && isDigit(recurrenceString[pos]) {
+			value, isOverflow = appendDigit(value, recurrenceString[pos], isOverflow)
+			pos++
+		}
+		if isOverflow || value > math.MaxInt {
+			return Recurrence{}, newParseError(recurrenceString, 1, ReasonOverflow)
+		}
+		count = int(value)
+	}
+
+	if pos >= len(recurrenceString) || recurrenceString[pos] != intervalSeparator {
+		return Recurrence{}, newParseError(recurrenceString, pos, ReasonInvalidDesignator)
+	}
+	pos++
+
+	interval, err := parseInterval(recurrenceString, pos)
+	if err != nil {
+		return Recurrence{}, err
+	}
+
+	out := Recurrence{interval: interval, step: interval.Duration(), count: count}
+	if err := out.validate(); err != nil {
+		return Recurrence{}, newParseError(recurrenceString, pos, ReasonInvalidInterval)
+	}
+
+	return out, nil
+}
+
+// validate reports whether the occurrences of r can be calculated.
+func (r Recurrence) validate() error {
+	switch {
+	case !r.interval.hasStart && !r.interval.hasEnd:
+		return ErrUnanchoredInterval
+	case !r.step.isPositive || r.step.IsZero():
+		return errors.New("iso8601: recurrence step must be positive")
+	case r.count < 0 && !r.interval.hasStart:
+		return errors.New("iso8601: unbounded recurrence requires a start")
+	}
+
+	return nil
+}
+
+// Interval returns the repeated interval.
+func (r Recurrence) Interval() Interval {
+	return r.interval
+}
+
+// Step returns the duration between two occurrences.
+func (r Recurrence) Step() Duration {
+	return r.step
+}
+
+// Count returns the number of occurrences. It reports false if the recurrence is unbounded.
+func (r Recurrence) Count() (int, bool) {
+	return r.count, r.count >= 0
+}
+
+// String returns the ISO 8601 representation of the recurrence, e.g. "R5/2024-01-01T09:00Z/P1W".
+func (r Recurrence) String() string {
+	buf := make([]byte, 0, 64)
+	buf = append(buf, recurrenceDesignator)
+	if r.count >= 0 {
+		buf = strconv.AppendInt(buf, int64(r.count), 10)
+	}
+	buf = append(buf, intervalSeparator)
+
+	return string(append(buf, r.interval.String()...))
+}
+
+// Next returns the first occurrence after the given time.
+// It reports false if there is none, as all occurrences of a bounded recurrence are not after it.
+func (r Recurrence) Next(after time.Time) (time.Time, bool, error) {
+	idx, err := r.index(after, true)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	if r.count >= 0 && idx >= r.count {
+		return time.Time{}, false, nil
+	}
+
+	out, err := r.occurrence(idx)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+
+	return out, true, nil
+}
+
+// Occurrences returns an iterator over all occurrences from from (inclusive) to to (exclusive) in ascending order.
+// The iteration stops early at the first occurrence that exceeds the range of time.Time.
+func (r Recurrence) Occurrences(from, to time.Time) iter.Seq[time.Time] {
+	return func(yield func(time.Time) bool) {
+		idx, err := r.index(from, false)
+		if err != nil {
+			return
+		}
+
+		for ; r.count < 0 || idx < r.count; idx++ {
+			occurrence, err := r.occurrence(idx)
+			if err != nil || !occurrence.Before(to) {
+				return
+			}
+			if !yield(occurrence) {
+				return
+			}
+		}
+	}
+}
+
+// recurrenceAddOptions are used to add multiples of the step to the anchor.
+var recurrenceAddOptions = AddOptions{MonthEnd: MonthEndClamp}
+
+// occurrence returns the occurrence with the zero-based index idx.
+func (r Recurrence) occurrence(idx int) (time.Time, error) {
+	anchor, factor, isForward := r.interval.start.time, idx, true
+	if !r.interval.hasStart {
+		// the last occurrence starts one step before the end of the interval
+		anchor, factor, isForward = r.interval.end.time, r.count-idx, false
+	}
+
+	// the calendar part is multiplied exactly, while the time part is added as elapsed time afterward,
+	// which is also how AddToTime adds them, so that it is not limited by the time.Duration range
+	calendar := Duration{
+		isPositive: true,
+		years:      r.step.years,
+		months:     r.step.months,
+		weeks:      r.step.weeks,
+		days:       r.step.days,
+	}
+	calendarOffset, err := calendar.Mul(factor)
+	if err != nil {
+		return time.Time{}, err
+	}
+	out, err := calendarOffset.applyToTime(anchor, isForward, recurrenceAddOptions)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	clock := Duration{isPositive: true, hours: r.step.hours, minutes: r.step.minutes, seconds: r.step.seconds}
+	clockStep, err := clock.ToTimeDuration()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return addElapsed(out, clockStep, factor, isForward)
+}
+
+// addElapsed adds factor times the non-negative step to t, or subtracts it if isForward is false.
+// Unlike time.Time.Add, the product may exceed the time.Duration range.
+func addElapsed(t time.Time, step time.Duration, factor int, isForward bool) (time.Time, error) {
+	if step == 0 || factor == 0 {
+		return t, nil
+	}
+
+	hi, lo := bits.Mul64(uint64(step), uint64(factor))
+	if hi >= uint64(time.Second) {
+		return time.Time{}, ErrOverflow
+	}
+	seconds, nanoseconds := bits.Div64(hi, lo, uint64(time.Second))
+	if seconds > math.MaxInt64/2 {
+		// far beyond the range of years of time.Time, but still safe to add to t.Unix()
+		return time.Time{}, ErrOverflow
+	}
+
+	if !isForward {
+		return time.Unix(t.Unix()-int64(seconds), int64(t.Nanosecond())-int64(nanoseconds)).In(t.Location()), nil
+	}
+
+	return time.Unix(t.Unix()+int64(seconds), int64(t.Nanosecond())+int64(nanoseconds)).In(t.Location()), nil
+}
+
+// index returns the index of the first occurrence after t, or not before t if isAfter is false.
+// For bounded recurrences, the result is at most the number of occurrences.
+func (r Recurrence) index(t time.Time, isAfter bool) (int, error) {
+	isBeyond := func(occurrence time.Time) bool {
+		return occurrence.After(t) || (!isAfter && occurrence.Equal(t))
+	}
+
+	idx := r.estimateIndex(t)
+	for idx > 0 {
+		occurrence, err := r.occurrence(idx - 1)
+		if err != nil {
+			return 0, err
+		}
+		if !isBeyond(occurrence) {
+			break
+		}
+		idx--
+	}
+
+	for r.count < 0 || idx < r.count {
+		occurrence, err := r.occurrence(idx)
+		if err != nil {
+			return 0, err
+		}
+		if isBeyond(occurrence) {
+			break
+		}
+		idx++
+	}
+
+	return idx, nil
+}
+
+// estimateIndex estimates the index of the occurrence at t using the average length of the step,
+// so that only a few occurrences have to be calculated to find the exact index.
+func (r Recurrence) estimateIndex(t time.Time) int {
+	parser := Parser{YearLength: TimeGregorianYear, MonthLength: TimeGregorianMonth}
+	step, err := r.step.ToTimeDurationNominal(parser)
+	if err != nil || step <= 0 {
+		return 0
+	}
+
+	first, err := r.occurrence(0)
+	if err != nil || !t.After(first) {
+		return 0
+	}
+
+	// the difference is not limited to the time.Duration range, and an estimate does not need to be exact
+	elapsed := float64(t.Unix()-first.Unix()) + float64(t.Nanosecond()-first.Nanosecond())/float64(time.Second)
+	estimate := elapsed / step.Seconds()
+	if r.count >= 0 {
+		estimate = min(estimate, float64(r.count))
+	}
+
+	return int(min(estimate, math.MaxInt/2))
+}
@@ -0,0 +1,319 @@
+package iso8601_test
+
+import (
+	"github.com/Achsion/iso8601/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"slices"
+	"testing"
+	"time"
+)
+
+func mustParseRecurrence(t require.TestingT, recurrenceString string) iso8601.Recurrence {
+	out, err := iso8601.ParseRecurrence(recurrenceString)
+	require.NoError(t, err)
+
+	return out
+}
+
+func TestParseRecurrence(t *testing.T) {
+	testCases := []struct {
+		input         string
+		expectedCount int
+		expectedOk    bool
+		expectedStep  string
+	}{
+		{input: "R5/2024-01-01T09:00Z/P1W", expectedCount: 5, expectedOk: true, expectedStep: "P1W"},
+		{input: "R/2024-01-01/PT15M", expectedCount: -1, expectedOk: false, expectedStep: "PT15M"},
+		{input: "R3/P1D/2024-01-10", expectedCount: 3, expectedOk: true, expectedStep: "P1D"},
+		{input: "R2/2024-01-01/2024-01-08", expectedCount: 2, expectedOk: true, expectedStep: "P7D"},
+		{input: "R0/2024-01-01T09:30:15Z/PT1H", expectedCount: 0, expectedOk: true, expectedStep: "PT1H"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			recurrence := mustParseRecurrence(t, tc.input)
+
+			count, ok := recurrence.Count()
+			assert.Equal(t, tc.expectedCount, count)
+			assert.Equal(t, tc.expectedOk, ok)
+			assert.Equal(t, tc.expectedStep, recurrence.Step().String())
+			assert.Equal(t, tc.input, recurrence.String())
+		})
+	}
+}
+
+func TestParseRecurrence_Error(t *testing.T) {
+	testCases := []struct {
+		name           string
+		input          string
+		expectedOffset int
+		expectedReason iso8601.ParseErrorReason
+	}{
+		{"empty", "", 0, iso8601.ReasonInvalidDesignator},
+		{"missing designator", "5/2024-01-01/P1D", 0, iso8601.ReasonInvalidDesignator},
+		{"missing separator", "R5", 2, iso8601.ReasonInvalidDesignator},
+		{"invalid count", "R5x/2024-01-01/P1D", 2, iso8601.ReasonInvalidDesignator},
+		{"count overflow", "R99999999999999999999/2024-01-01/P1D", 1, iso8601.ReasonOverflow},
+		{"unanchored", "R5/P1D", 3, iso8601.ReasonInvalidInterval},
+		{"unbounded without start", "R/P1D/2024-01-10", 2, iso8601.ReasonInvalidInterval},
+		{"zero step", "R5/2024-01-01/P0D", 3, iso8601.ReasonInvalidInterval},
+		{"empty interval", "R5/2024-01-01/2024-01-01", 3, iso8601.ReasonInvalidInterval},
+		{"invalid interval", "R5/2024-01-01/P1X", 16, iso8601.ReasonInvalidDesignator},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := iso8601.ParseRecurrence(tc.input)
+
+			var parseErr *iso8601.ParseError
+			require.ErrorAs(t, err, &parseErr)
+			assert.Equal(t, tc.input, parseErr.Input)
+			assert.Equal(t, tc.expectedOffset, parseErr.Offset)
+			assert.Equal(t, tc.expectedReason, parseErr.Reason)
+		})
+	}
+}
+
+func TestRecurrenceFromInterval(t *testing.T) {
+	start := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	interval := iso8601.IntervalFromStartDuration(start, mustDurationFromString(t, "P1W"))
+
+	recurrence, err := iso8601.RecurrenceFromInterval(interval, 5)
+	require.NoError(t, err)
+	assert.Equal(t, "R5/2024-01-01T09:00Z/P1W", recurrence.String())
+
+	recurrence, err = iso8601.RecurrenceFromInterval(interval, -3)
+	require.NoError(t, err)
+	assert.Equal(t, "R/2024-01-01T09:00Z/P1W", recurrence.String())
+
+	_, err = iso8601.RecurrenceFromInterval(iso8601.IntervalFromDuration(mustDurationFromString(t, "P1W")), 5)
+	assert.ErrorIs(t, err, iso8601.ErrUnanchoredInterval)
+
+	_, err = iso8601.RecurrenceFromInterval(iso8601.IntervalFromStartDuration(start, mustDurationFromString(t, "-P1W")), 5)
+	assert.Error(t, err)
+}
+
+func TestRecurrence_Occurrences(t *testing.T) {
+	testCases := []struct {
+		name       string
+		recurrence string
+		from       time.Time
+		to         time.Time
+		expected   []time.Time
+	}{
+		{
+			name:       "all of a bounded recurrence",
+			recurrence: "R3/2024-01-01T09:00Z/P1W",
+			from:       time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC),
+			to:         time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC),
+				time.Date(2024, 1, 8, 9, 0, 0, 0, time.UTC),
+				time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "from is inclusive and to is exclusive",
+			recurrence: "R5/2024-01-01T09:00Z/P1W",
+			from:       time.Date(2024, 1, 8, 9, 0, 0, 0, time.UTC),
+			to:         time.Date(2024, 1, 22, 9, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2024, 1, 8, 9, 0, 0, 0, time.UTC),
+				time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "window of an unbounded recurrence",
+			recurrence: "R/2024-01-01/PT15M",
+			from:       time.Date(2024, 3, 15, 10, 7, 0, 0, time.UTC),
+			to:         time.Date(2024, 3, 15, 10, 50, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2024, 3, 15, 10, 15, 0, 0, time.UTC),
+				time.Date(2024, 3, 15, 10, 30, 0, 0, time.UTC),
+				time.Date(2024, 3, 15, 10, 45, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "month ends are clamped without drift",
+			recurrence: "R/2024-01-31T00:00Z/P1M",
+			from:       time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			to:         time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 4, 30, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "leap day is clamped in other years",
+			recurrence: "R/2024-02-29T00:00Z/P1Y",
+			from:       time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			to:         time.Date(2029, 1, 1, 0, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+				time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC),
+				time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC),
+				time.Date(2027, 2, 28, 0, 0, 0, 0, time.UTC),
+				time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "anchored at the end with clamped month ends",
+			recurrence: "R3/P1M/2024-03-31",
+			from:       time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC),
+			to:         time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "sub-day step beyond the time.Duration range",
+			recurrence: "R/2024-01-01T00:00Z/PT1M",
+			from:       time.Date(2400, 1, 1, 0, 0, 0, 0, time.UTC),
+			to:         time.Date(2400, 1, 1, 0, 3, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2400, 1, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2400, 1, 1, 0, 1, 0, 0, time.UTC),
+				time.Date(2400, 1, 1, 0, 2, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "anchored at the end",
+			recurrence: "R3/P1D/2024-01-10",
+			from:       time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			to:         time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			expected: []time.Time{
+				time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC),
+				time.Date(2024, 1, 9, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:       "zero occurrences",
+			recurrence: "R0/2024-01-01/P1D",
+			from:       time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			to:         time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			expected:   nil,
+		},
+		{
+			name:       "window after the last occurrence",
+			recurrence: "R2/2024-01-01/P1D",
+			from:       time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
+			to:         time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			expected:   nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			recurrence := mustParseRecurrence(t, tc.recurrence)
+
+			actual := slices.Collect(recurrence.Occurrences(tc.from, tc.to))
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestRecurrence_Occurrences_Break(t *testing.T) {
+	recurrence := mustParseRecurrence(t, "R/2024-01-01/PT15M")
+
+	var actual []time.Time
+	for occurrence := range recurrence.Occurrences(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Now()) {
+		actual = append(actual, occurrence)
+		if len(actual) == 2 {
+			break
+		}
+	}
+
+	assert.Equal(t, []time.Time{
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 1, 0, 15, 0, 0, time.UTC),
+	}, actual)
+}
+
+func TestRecurrence_Next(t *testing.T) {
+	testCases := []struct {
+		name       string
+		recurrence string
+		after      time.Time
+		expected   time.Time
+		expectedOk bool
+	}{
+		{
+			name:       "before the first occurrence",
+			recurrence: "R5/2024-01-01T09:00Z/P1W",
+			after:      time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+			expected:   time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC),
+			expectedOk: true,
+		},
+		{
+			name:       "exactly at an occurrence",
+			recurrence: "R5/2024-01-01T09:00Z/P1W",
+			after:      time.Date(2024, 1, 8, 9, 0, 0, 0, time.UTC),
+			expected:   time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC),
+			expectedOk: true,
+		},
+		{
+			name:       "after the last occurrence",
+			recurrence: "R5/2024-01-01T09:00Z/P1W",
+			after:      time.Date(2024, 1, 29, 9, 0, 0, 0, time.UTC),
+			expectedOk: false,
+		},
+		{
+			name:       "far into an unbounded recurrence",
+			recurrence: "R/2024-01-01/PT15M",
+			after:      time.Date(2124, 6, 1, 10, 7, 0, 0, time.UTC),
+			expected:   time.Date(2124, 6, 1, 10, 15, 0, 0, time.UTC),
+			expectedOk: true,
+		},
+		{
+			name:       "sub-day step beyond the time.Duration range",
+			recurrence: "R/2024-01-01T00:00Z/PT1M",
+			after:      time.Date(2400, 1, 1, 0, 0, 0, 0, time.UTC),
+			expected:   time.Date(2400, 1, 1, 0, 1, 0, 0, time.UTC),
+			expectedOk: true,
+		},
+		{
+			name:       "fractional seconds beyond the time.Duration range",
+			recurrence: "R/2024-01-01T00:00Z/PT0.25S",
+			after:      time.Date(2500, 6, 1, 12, 0, 0, 100, time.UTC),
+			expected:   time.Date(2500, 6, 1, 12, 0, 0, 250_000_000, time.UTC),
+			expectedOk: true,
+		},
+		{
+			name:       "clamped month end in a later year",
+			recurrence: "R/2024-01-31T00:00Z/P1M",
+			after:      time.Date(2125, 2, 1, 0, 0, 0, 0, time.UTC),
+			expected:   time.Date(2125, 2, 28, 0, 0, 0, 0, time.UTC),
+			expectedOk: true,
+		},
+		{
+			name:       "many month steps without drift",
+			recurrence: "R/2024-01-31T00:00Z/P1M",
+			after:      time.Date(2124, 1, 1, 0, 0, 0, 0, time.UTC),
+			expected:   time.Date(2124, 1, 31, 0, 0, 0, 0, time.UTC),
+			expectedOk: true,
+		},
+		{
+			name:       "many year steps from a leap day",
+			recurrence: "R/2024-02-29/P1Y",
+			after:      time.Date(2399, 12, 31, 0, 0, 0, 0, time.UTC),
+			expected:   time.Date(2400, 2, 29, 0, 0, 0, 0, time.UTC),
+			expectedOk: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, ok, err := mustParseRecurrence(t, tc.recurrence).Next(tc.after)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedOk, ok)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package iso8601
+
+// durationUnit enumerates the units of a Duration in the order they have to appear in a duration string.
+type durationUnit int
+
+const (
+	noUnit durationUnit = iota
+	yearUnit
+	monthUnit
+	weekUnit
+	dayUnit
+	hourUnit
+	minuteUnit
+	secondUnit
+)
+
+// durationUnitFromDesignator returns the durationUnit for the given designator,
+// or noUnit if the designator is not valid in the current (date or time) section.
+func durationUnitFromDesignator(designator byte, interpretDate bool) durationUnit {
+	if interpretDate {
+		switch designator {
+		case yearDesignator:
+			return yearUnit
+		case monthDesignator:
+			return monthUnit
+		case weekDesignator:
+			return weekUnit
+		case dayDesignator:
+			return dayUnit
+		}
+
+		return noUnit
+	}
+
+	switch designator {
+	case hourDesignator:
+		return hourUnit
+	case minuteDesignator:
+		return minuteUnit
+	case secondDesignator:
+		return secondUnit
+	}
+
+	return noUnit
+}
+
+func isDigit(char byte) bool {
+	return '0' <= char && char <= '9'
+}
+
+// durationComponent is a single value-designator pair of a duration string, e.g. "1.5H".
+type durationComponent struct {
+	unit durationUnit
+
+	// value is the integer part of the component
+	value uint64
+	// isValueOverflow reports whether value exceeds the uint64 range
+	isValueOverflow bool
+	// fraction is the decimal fraction of the component as fraction/10**fractionDigits.
+	// Only the first maxFractionDigits digits are taken into account.
+	fraction       uint64
+	fractionDigits int
+
+	// valueOffset is the byte offset of the value in the duration string
+	valueOffset int
+	// designatorOffset is the byte offset of the designator in the duration string
+	designatorOffset int
+}
+
+// durationScanner splits an ISO 8601 duration string into its components in a single pass
+// and validates its format. It never allocates, except for returned errors.
+type durationScanner struct {
+	input string
+	pos   int
+
+	isNegative    bool
+	interpretDate bool
+	lastUnit      durationUnit
+	hasFraction   bool
+	timeParts     int
+}
+
+// newDurationScanner consumes the sign and start designator of durationString.
+func newDurationScanner(durationString string) (durationScanner, error) {
+	scanner := durationScanner{
+		input:         durationString,
+		interpretDate: true,
+	}
+
+	// consume [-]?
+	if scanner.pos < len(durationString) && durationString[scanner.pos] == '-' {
+		scanner.isNegative = true
+		scanner.pos++
+	}
+
+	if scanner.pos >= len(durationString) || durationString[scanner.pos] != startDesignator {
+		// duration string has to start with 'P' or '-P'
+		return durationScanner{}, newParseError(durationString, scanner.pos, ReasonMissingStartDesignator)
+	}
+	scanner.pos++
+
+	return scanner, nil
+}
+
+// next scans the next component of the duration string. It reports false when the end of
+// the duration string has been reached and the complete string has been validated.
+func (s *durationScanner) next() (durationComponent, bool, error) {
+	if s.pos < len(s.input) && s.input[s.pos] == timeSwitchDesignator {
+		if !s.interpretDate {
+			return durationComponent{}, false, newParseError(s.input, s.pos, ReasonDuplicateDesignator)
+		}
+		s.interpretDate = false
+		s.pos++
+	}
+
+	if s.pos >= len(s.input) {
+		if !s.interpretDate && s.timeParts == 0 {
+			return durationComponent{}, false, newParseError(s.input, s.pos, ReasonEmptyTimeSection)
+		}
+		if s.lastUnit == noUnit {
+			// the shortest possible duration string contains one designator (e.g. "P3D")
+			return durationComponent{}, false, newParseError(s.input, s.pos, ReasonMissingValue)
+		}
+
+		return durationComponent{}, false, nil
+	}
+
+	if s.hasFraction {
+		// only the lowest-order component may contain a decimal fraction
+		return durationComponent{}, false, newParseError(s.input, s.pos, ReasonInvalidFraction)
+	}
+
+	out := durationComponent{valueOffset: s.pos}
+	for s.pos < len(s.input) && isDigit(s.input[s.pos]) {
+		out.value, out.isValueOverflow = appendDigit(out.value, s.input[s.pos], out.isValueOverflow)
+		s.pos++
+	}
+	hasValue := s.pos > out.valueOffset
+
+	if s.pos < len(s.input) && isDecimalSeparator(s.input[s.pos]) {
+		separatorPos := s.pos
+		s.pos++
+
+		fractionStart := s.pos
+		for s.pos < len(s.input) && isDigit(s.input[s.pos]) {
+			if out.fractionDigits < maxFractionDigits {
+				out.fraction = out.fraction*10 + uint64(s.input[s.pos]-'0')
+				out.fractionDigits++
+			}
+			s.pos++
+		}
+
+		if !hasValue {
+			return durationComponent{}, false, newParseError(s.input, separatorPos, ReasonInvalidFraction)
+		}
+		if s.pos == fractionStart || (s.pos < len(s.input) && isDecimalSeparator(s.input[s.pos])) {
+			// no fraction digits / multiple decimal separators
+			return durationComponent{}, false, newParseError(s.input, s.pos, ReasonInvalidFraction)
+		}
+		s.hasFraction = true
+	}
+
+	if s.pos >= len(s.input) {
+		return durationComponent{}, false, newParseError(s.input, s.pos, ReasonMissingDesignator)
+	}
+
+	out.designatorOffset = s.pos
+	out.unit = durationUnitFromDesignator(s.input[s.pos], s.interpretDate)
+	switch {
+	case out.unit == noUnit:
+		return durationComponent{}, false, newParseError(s.input, s.pos, ReasonInvalidDesignator)
+	case !hasValue:
+		return durationComponent{}, false, newParseError(s.input, s.pos, ReasonMissingValue)
+	case out.unit == s.lastUnit:
+		return durationComponent{}, false, newParseError(s.input, s.pos, ReasonDuplicateDesignator)
+	case out.unit < s.lastUnit:
+		return durationComponent{}, false, newParseError(s.input, s.pos, ReasonDesignatorOutOfOrder)
+	}
+
+	s.lastUnit = out.unit
+	if !s.interpretDate {
+		s.timeParts++
+	}
+	s.pos++
+
+	return out, true, nil
+}
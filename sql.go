@@ -0,0 +1,253 @@
+package iso8601
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Scan implements sql.Scanner. It accepts strings and byte slices in the following formats:
+//   - ISO 8601 durations as accepted by DurationFromString, e.g. "P1Y2M3DT4H5M6.5S"
+//   - the iso_8601 interval style of PostgreSQL with a sign on every component, e.g. "P-1Y-2M-3DT-4H-5M-6.5S"
+//   - the default postgres interval style of PostgreSQL, e.g. "1 year 2 mons 3 days 04:05:06.5"
+//
+// Like PostgreSQL, years, months, days and the time of day are kept as separate components.
+// As a Duration has a single sign, intervals whose components have different signs result in ErrMixedSigns.
+func (d *Duration) Scan(src any) error {
+	var durationString string
+	switch value := src.(type) {
+	case string:
+		durationString = value
+	case []byte:
+		durationString = string(value)
+	case nil:
+		return errors.New("iso8601: cannot scan NULL into Duration")
+	default:
+		return fmt.Errorf("iso8601: cannot scan %T into Duration", src)
+	}
+
+	out, err := durationFromSQL(strings.TrimSpace(durationString))
+	if err != nil {
+		return err
+	}
+	*d = out
+
+	return nil
+}
+
+// Value implements driver.Valuer by encoding the duration as ISO 8601 string.
+// Negative durations are written with a sign on every component, e.g. "P-1DT-2H" for "-P1DT2H",
+// as PostgreSQL does not accept a leading sign.
+func (d Duration) Value() (driver.Value, error) {
+	if d.isPositive || d.IsZero() {
+		// a negative zero is written without a sign
+		return d.Abs().String(), nil
+	}
+
+	abs := d.Abs().String()
+	out := make([]byte, 0, 2*len(abs))
+	for i := 0; i < len(abs); i++ {
+		if isDigit(abs[i]) && !isDigit(abs[i-1]) && !isDecimalSeparator(abs[i-1]) {
+			// the first digit of a component, as the string always starts with the start designator
+			out = append(out, '-')
+		}
+		out = append(out, abs[i])
+	}
+
+	return string(out), nil
+}
+
+// durationFromSQL parses an interval in any of the formats accepted by Duration.Scan.
+func durationFromSQL(durationString string) (Duration, error) {
+	if strings.HasPrefix(durationString, "-") || !strings.HasPrefix(durationString, "P") {
+		if strings.HasPrefix(durationString, "-P") {
+			return DurationFromString(durationString)
+		}
+
+		return durationFromPostgres(durationString)
+	}
+
+	if !strings.ContainsAny(durationString, "+-") {
+		return DurationFromString(durationString)
+	}
+
+	// the iso_8601 interval style has a sign on every component
+	out := make([]byte, 0, len(durationString)+1)
+	out = append(out, '-')
+	components := 0
+	negatives := 0
+	isInComponent := false
+	for i := 0; i < len(durationString); i++ {
+		char := durationString[i]
+		switch {
+		case (char == '-' || char == '+') && !isInComponent:
+			components++
+			if char == '-' {
+				negatives++
+			}
+			isInComponent = true
+
+			continue
+		case isDigit(char) || isDecimalSeparator(char):
+			if !isInComponent {
+				components++
+				isInComponent = true
+			}
+		default:
+			isInComponent = false
+		}
+		out = append(out, char)
+	}
+
+	switch negatives {
+	case 0:
+		return DurationFromString(string(out[1:]))
+	case components:
+		return DurationFromString(string(out))
+	}
+
+	return Duration{}, fmt.Errorf("iso8601: cannot scan %q: %w", durationString, ErrMixedSigns)
+}
+
+// durationFromPostgres parses an interval in the default postgres interval style of PostgreSQL,
+// e.g. "1 year 2 mons 3 days 04:05:06.5" or "-1 years -2 mons -3 days -04:05:06".
+func durationFromPostgres(durationString string) (Duration, error) {
+	invalidErr := fmt.Errorf("iso8601: cannot scan %q: invalid interval", durationString)
+
+	fields := strings.Fields(durationString)
+	if len(fields) == 0 {
+		return Duration{}, invalidErr
+	}
+
+	out := Duration{}
+	components := 0
+	negatives := 0
+	lastUnit := noUnit
+
+	for i := 0; i < len(fields); i++ {
+		field := fields[i]
+
+		isNegative := false
+		if field[0] == '-' || field[0] == '+' {
+			isNegative = field[0] == '-'
+			field = field[1:]
+		}
+		components++
+		if isNegative {
+			negatives++
+		}
+
+		if strings.IndexByte(field, timeSeparator) >= 0 {
+			if i != len(fields)-1 || !out.setPostgresTime(field) {
+				return Duration{}, invalidErr
+			}
+
+			break
+		}
+
+		if i+1 >= len(fields) {
+			return Duration{}, invalidErr
+		}
+		i++
+
+		unit := postgresUnit(fields[i])
+		value, ok := decimalFromSQLNumber(field)
+		if unit <= lastUnit || !ok {
+			return Duration{}, invalidErr
+		}
+		*out.unitValue(unit) = value
+		lastUnit = unit
+	}
+
+	switch negatives {
+	case 0:
+		out.isPositive = true
+	case components:
+		out.isPositive = false
+	default:
+		return Duration{}, fmt.Errorf("iso8601: cannot scan %q: %w", durationString, ErrMixedSigns)
+	}
+
+	return out, nil
+}
+
+// postgresUnit returns the durationUnit of a unit of the postgres interval style, or noUnit if it is unknown.
+func postgresUnit(unit string) durationUnit {
+	switch unit {
+	case "year", "years":
+		return yearUnit
+	case "mon", "mons":
+		return monthUnit
+	case "day", "days":
+		return dayUnit
+	}
+
+	return noUnit
+}
+
+// setPostgresTime sets the hours, minutes and seconds of d from the unsigned time "hh:mm:ss[.ffffff]".
+// It reports false if the time is malformed.
+func (d *Duration) setPostgresTime(clock string) bool {
+	parts := strings.Split(clock, string(timeSeparator))
+	if len(parts) != 3 || strings.ContainsAny(parts[0]+parts[1], ".,") {
+		return false
+	}
+
+	hours, ok := decimalFromSQLNumber(parts[0])
+	if !ok {
+		return false
+	}
+	minutes, ok := decimalFromSQLNumber(parts[1])
+	if !ok || minutes.whole >= 60 {
+		return false
+	}
+	seconds, ok := decimalFromSQLNumber(parts[2])
+	if !ok || seconds.whole >= 60 {
+		return false
+	}
+
+	d.hours = hours
+	d.minutes = minutes
+	d.seconds = seconds
+
+	return true
+}
+
+// decimalFromSQLNumber parses an unsigned decimal number with an optional fraction separated by a full stop.
+// Fraction digits beyond the precision of a decimal are truncated.
+// It reports false if the number is malformed or exceeds the supported range.
+func decimalFromSQLNumber(number string) (decimal, bool) {
+	var value, fraction uint64
+	isOverflow := false
+	fractionDigits := 0
+
+	pos := 0
+	for pos < len(number) && isDigit(number[pos]) {
+		value, isOverflow = appendDigit(value, number[pos], isOverflow)
+		pos++
+	}
+	if pos == 0 || isOverflow {
+		return decimal{}, false
+	}
+
+	if pos < len(number) && number[pos] == decimalPointDesignator {
+		pos++
+		fractionStart := pos
+		for pos < len(number) && isDigit(number[pos]) {
+			if fractionDigits < decimalPrecision {
+				fraction = fraction*10 + uint64(number[pos]-'0')
+				fractionDigits++
+			}
+			pos++
+		}
+		if pos == fractionStart {
+			return decimal{}, false
+		}
+	}
+	if pos != len(number) {
+		return decimal{}, false
+	}
+
+	return decimalFromComponent(value, fraction, fractionDigits), true
+}
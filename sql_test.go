@@ -0,0 +1,129 @@
+package iso8601_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"github.com/Achsion/iso8601/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+var (
+	_ sql.Scanner   = (*iso8601.Duration)(nil)
+	_ driver.Valuer = iso8601.Duration{}
+)
+
+func TestDuration_Scan(t *testing.T) {
+	testCases := []struct {
+		name     string
+		src      any
+		expected string
+	}{
+		{name: "iso", src: "P1Y2M3DT4H5M6.5S", expected: "P1Y2M3DT4H5M6.5S"},
+		{name: "iso negative", src: "-P1D", expected: "-P1D"},
+		{name: "iso bytes", src: []byte("PT1H"), expected: "PT1H"},
+		{name: "iso zero", src: "PT0S", expected: "PT0S"},
+		{name: "iso component signs", src: "P-1Y-2M-3DT-4H-5M-6.5S", expected: "-P1Y2M3DT4H5M6.5S"},
+		{name: "iso component signs single", src: "PT-0.000001S", expected: "-PT0.000001S"},
+		{name: "iso component plus signs", src: "P+1Y+2M", expected: "P1Y2M"},
+		{name: "postgres", src: "1 year 2 mons 3 days 04:05:06.5", expected: "P1Y2M3DT4H5M6.5S"},
+		{name: "postgres bytes", src: []byte("1 day"), expected: "P1D"},
+		{name: "postgres plural", src: "2 years 1 mon 2 days", expected: "P2Y1M2D"},
+		{name: "postgres negative", src: "-1 years -2 mons -3 days -04:05:06", expected: "-P1Y2M3DT4H5M6S"},
+		{name: "postgres zero", src: "00:00:00", expected: "PT0S"},
+		{name: "postgres time only", src: "-00:00:01.25", expected: "-PT1.25S"},
+		{name: "postgres long hours", src: "100:30:00", expected: "PT100H30M"},
+		{name: "postgres microseconds", src: "1 day 00:00:00.000001", expected: "P1DT0.000001S"},
+		{name: "postgres months not normalized", src: "14 mons 40 days", expected: "P14M40D"},
+		{name: "surrounding whitespace", src: " 3 days ", expected: "P3D"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var actual iso8601.Duration
+			require.NoError(t, actual.Scan(tc.src))
+
+			assert.True(t, mustDurationFromString(t, tc.expected).StrictEqual(actual), actual.String())
+		})
+	}
+}
+
+func TestDuration_Scan_Error(t *testing.T) {
+	testCases := []struct {
+		name string
+		src  any
+	}{
+		{name: "nil", src: nil},
+		{name: "unsupported type", src: int64(5)},
+		{name: "empty", src: ""},
+		{name: "garbage", src: "abc"},
+		{name: "iso invalid designator", src: "P1X"},
+		{name: "iso double sign", src: "P--1D"},
+		{name: "iso sign inside number", src: "P1-2D"},
+		{name: "postgres unknown unit", src: "1 fortnight"},
+		{name: "postgres missing unit", src: "1"},
+		{name: "postgres duplicate unit", src: "1 day 2 days"},
+		{name: "postgres units out of order", src: "1 day 1 year"},
+		{name: "postgres time not last", src: "04:05:06 1 day"},
+		{name: "postgres short time", src: "04:05"},
+		{name: "postgres minutes out of range", src: "04:60:00"},
+		{name: "postgres fractional hours", src: "04.5:00:00"},
+		{name: "postgres missing fraction digits", src: "00:00:01."},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := mustDurationFromString(t, "P1D")
+			assert.Error(t, actual.Scan(tc.src))
+			assert.Equal(t, "P1D", actual.String(), "the duration must not be changed on error")
+		})
+	}
+}
+
+func TestDuration_Scan_MixedSigns(t *testing.T) {
+	for _, src := range []string{"1 mon -1 days", "-1 years +2 mons", "1 day -00:00:01", "P1Y-2M", "P-1DT1H"} {
+		t.Run(src, func(t *testing.T) {
+			var actual iso8601.Duration
+			assert.ErrorIs(t, actual.Scan(src), iso8601.ErrMixedSigns)
+		})
+	}
+}
+
+func TestDuration_Value(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{input: "PT0S", expected: "PT0S"},
+		{input: "-PT0S", expected: "PT0S"},
+		{input: "P1Y2M3DT4H5M6.5S", expected: "P1Y2M3DT4H5M6.5S"},
+		{input: "-P1Y2M3W4DT5H6M7.5S", expected: "P-1Y-2M-3W-4DT-5H-6M-7.5S"},
+		{input: "-PT0.000001S", expected: "PT-0.000001S"},
+		{input: "-P10D", expected: "P-10D"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			actual, err := mustDurationFromString(t, tc.input).Value()
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestDuration_Value_RoundTrip(t *testing.T) {
+	for _, input := range encodingFixtures {
+		t.Run(input, func(t *testing.T) {
+			expected := mustDurationFromString(t, input)
+
+			value, err := expected.Value()
+			require.NoError(t, err)
+
+			var actual iso8601.Duration
+			require.NoError(t, actual.Scan(value))
+			// the sign of a negative zero is not kept
+			assert.True(t, expected.StrictEqual(actual), actual.String())
+		})
+	}
+}
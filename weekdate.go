@@ -0,0 +1,165 @@
+package iso8601
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// WeekDate is an ISO 8601 week date, e.g. "2024-W11-5".
+// Its year is the ISO week-numbering year, which differs from the Gregorian year around January 1:
+// week 1 is the week containing the first Thursday of the year, so it may start in December of the previous year,
+// and the last days of December may belong to week 1 of the following year.
+type WeekDate struct {
+	// Year is the ISO week-numbering year.
+	Year int
+	// Week is the week of the year, from 1 to 52 or 53.
+	Week int
+	// Day is the day of the week, from 1 for Monday to 7 for Sunday.
+	Day int
+}
+
+// WeekDateOf returns the WeekDate of the calendar date of t.
+func WeekDateOf(t time.Time) WeekDate {
+	year, week := t.ISOWeek()
+
+	return WeekDate{Year: year, Week: week, Day: isoWeekday(t.Weekday())}
+}
+
+// Time returns midnight of the week date in loc.
+// Values out of range are normalized like time.Date does, e.g. week 54 results in a date of the following year.
+func (w WeekDate) Time(loc *time.Location) time.Time {
+	// January 4 is always in week 1
+	january4 := time.Date(w.Year, time.January, 4, 0, 0, 0, 0, time.UTC)
+	day := 4 - isoWeekday(january4.Weekday()) + 1 + (w.Week-1)*7 + (w.Day - 1)
+
+	return time.Date(w.Year, time.January, day, 0, 0, 0, 0, loc)
+}
+
+// String returns the week date in the extended format, e.g. "2024-W11-5".
+func (w WeekDate) String() string {
+	return w.Format(FormatExtended)
+}
+
+// Format returns the week date in the given format, e.g. "2024-W11-5" or "2024W115".
+func (w WeekDate) Format(style FormatStyle) string {
+	var arr [32]byte
+
+	return string(appendWeekDate(arr[:0], w, style))
+}
+
+// AddDuration adds a Duration consisting of whole weeks only to the week date, which keeps the day of the week.
+// It returns an error if the duration contains any other component or a fractional week.
+func (w WeekDate) AddDuration(d Duration) (WeekDate, error) {
+	if !d.years.isZero() || !d.months.isZero() || !d.days.isZero() ||
+		!d.hours.isZero() || !d.minutes.isZero() || !d.seconds.isZero() {
+		return WeekDate{}, errors.New("duration must only contain weeks")
+	}
+
+	weeks, err := d.weeks.int()
+	if err != nil || weeks > math.MaxInt/7 {
+		return WeekDate{}, errors.New("could not convert week to int")
+	}
+	if !d.isPositive {
+		weeks = -weeks
+	}
+
+	return WeekDateOf(w.Time(time.UTC).AddDate(0, 0, 7*weeks)), nil
+}
+
+// ParseWeekDate parses an ISO 8601 week date in the extended format "YYYY-Www-D" or the basic format "YYYYWwwD"
+// into a time.Time at midnight UTC. The day of the week may be omitted, e.g. "2024-W11", which results in its Monday.
+// Use WeekDateOf to convert the result into a WeekDate.
+func ParseWeekDate(weekDateString string) (time.Time, error) {
+	scanner := dateTimeScanner{input: weekDateString}
+
+	weekDate, err := scanner.scanWeekDate()
+	if err != nil {
+		return time.Time{}, err
+	}
+	if err := scanner.end(); err != nil {
+		return time.Time{}, err
+	}
+
+	return weekDate.Time(time.UTC), nil
+}
+
+// FormatWeekDate returns the ISO 8601 week date of t, e.g. "2024-W11-5" or "2024W115".
+func FormatWeekDate(t time.Time, style FormatStyle) string {
+	return WeekDateOf(t).Format(style)
+}
+
+// appendWeekDate appends the week date w to buf.
+func appendWeekDate(buf []byte, w WeekDate, style FormatStyle) []byte {
+	buf = appendYear(buf, w.Year)
+	if style == FormatExtended {
+		buf = append(buf, dateSeparator)
+	}
+	buf = append(buf, weekDesignator)
+	buf = appendInt(buf, w.Week, 2)
+	if style == FormatExtended {
+		buf = append(buf, dateSeparator)
+	}
+
+	return appendInt(buf, w.Day, 1)
+}
+
+// isoWeekday returns the ISO 8601 number of weekday, from 1 for Monday to 7 for Sunday.
+func isoWeekday(weekday time.Weekday) int {
+	if weekday == time.Sunday {
+		return 7
+	}
+
+	return int(weekday)
+}
+
+// weeksInYear returns the number of weeks of the ISO week-numbering year, either 52 or 53.
+func weeksInYear(year int) int {
+	// December 28 is always in the last week of the year
+	_, week := time.Date(year, time.December, 28, 0, 0, 0, 0, time.UTC).ISOWeek()
+
+	return week
+}
+
+// scanWeekDate scans a week date "YYYY-Www[-D]" or "YYYYWww[D]".
+func (s *dateTimeScanner) scanWeekDate() (WeekDate, error) {
+	year, err := s.digits(4)
+	if err != nil {
+		return WeekDate{}, err
+	}
+
+	if err := s.separator(dateSeparator); err != nil {
+		return WeekDate{}, err
+	}
+	if s.pos >= len(s.input) || s.input[s.pos] != weekDesignator {
+		return WeekDate{}, newParseError(s.input, s.pos, ReasonInvalidDesignator)
+	}
+	s.pos++
+
+	weekOffset := s.pos
+	week, err := s.digits(2)
+	if err != nil {
+		return WeekDate{}, err
+	}
+	if week < 1 || week > weeksInYear(year) {
+		return WeekDate{}, newParseError(s.input, weekOffset, ReasonFieldRange)
+	}
+
+	day := 1
+	if s.pos < len(s.input) && (s.input[s.pos] == dateSeparator || isDigit(s.input[s.pos])) {
+		if err := s.separator(dateSeparator); err != nil {
+			return WeekDate{}, err
+		}
+
+		dayOffset := s.pos
+		day, err = s.digits(1)
+		if err != nil {
+			return WeekDate{}, err
+		}
+		if day < 1 || day > 7 {
+			return WeekDate{}, newParseError(s.input, dayOffset, ReasonFieldRange)
+		}
+	}
+
+	return WeekDate{Year: year, Week: week, Day: day}, nil
+}
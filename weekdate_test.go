@@ -0,0 +1,169 @@
+package iso8601_test
+
+import (
+	"github.com/Achsion/iso8601/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestParseWeekDate(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected time.Time
+	}{
+		{input: "2024-W11-5", expected: time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)},
+		{input: "2024W115", expected: time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)},
+		{input: "2024-W11", expected: time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC)},
+		{input: "2024W11", expected: time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC)},
+		{input: "2024-W01-1", expected: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{input: "2025-W01-1", expected: time.Date(2024, 12, 30, 0, 0, 0, 0, time.UTC)},
+		{input: "2020-W53-5", expected: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{input: "2026-W53-7", expected: time.Date(2027, 1, 3, 0, 0, 0, 0, time.UTC)},
+		{input: "2015-W53-4", expected: time.Date(2015, 12, 31, 0, 0, 0, 0, time.UTC)},
+		{input: "2010-W01-1", expected: time.Date(2010, 1, 4, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			actual, err := iso8601.ParseWeekDate(tc.input)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestParseWeekDate_Error(t *testing.T) {
+	testCases := []struct {
+		name           string
+		input          string
+		expectedOffset int
+		expectedReason iso8601.ParseErrorReason
+	}{
+		{"missing week designator", "2024-11-5", 5, iso8601.ReasonInvalidDesignator},
+		{"lowercase week designator", "2024w115", 4, iso8601.ReasonInvalidDesignator},
+		{"week zero", "2024-W00-1", 6, iso8601.ReasonFieldRange},
+		{"week 53 in a 52 week year", "2021-W53-1", 6, iso8601.ReasonFieldRange},
+		{"week 54", "2020W541", 5, iso8601.ReasonFieldRange},
+		{"day zero", "2024-W11-0", 9, iso8601.ReasonFieldRange},
+		{"day eight", "2024W118", 7, iso8601.ReasonFieldRange},
+		{"short week", "2024-W1-5", 7, iso8601.ReasonMissingDigits},
+		{"mixed formats", "2024-W115", 8, iso8601.ReasonMixedFormats},
+		{"trailing characters", "2024-W11-51", 10, iso8601.ReasonInvalidDesignator},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := iso8601.ParseWeekDate(tc.input)
+
+			var parseErr *iso8601.ParseError
+			require.ErrorAs(t, err, &parseErr)
+			assert.Equal(t, tc.expectedOffset, parseErr.Offset)
+			assert.Equal(t, tc.expectedReason, parseErr.Reason)
+		})
+	}
+}
+
+func TestFormatWeekDate(t *testing.T) {
+	testCases := []struct {
+		stdTime       time.Time
+		expected      string
+		expectedBasic string
+	}{
+		{time.Date(2024, 3, 15, 14, 30, 0, 0, time.UTC), "2024-W11-5", "2024W115"},
+		{time.Date(2024, 12, 30, 0, 0, 0, 0, time.UTC), "2025-W01-1", "2025W011"},
+		{time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC), "2020-W53-7", "2020W537"},
+		{time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC), "2026-W53-5", "2026W535"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expected, func(t *testing.T) {
+			assert.Equal(t, tc.expected, iso8601.FormatWeekDate(tc.stdTime, iso8601.FormatExtended))
+			assert.Equal(t, tc.expectedBasic, iso8601.FormatWeekDate(tc.stdTime, iso8601.FormatBasic))
+		})
+	}
+}
+
+func TestWeekDate_RoundTrip(t *testing.T) {
+	for day := time.Date(2019, 12, 1, 0, 0, 0, 0, time.UTC); day.Year() < 2028; day = day.AddDate(0, 0, 1) {
+		weekDate := iso8601.WeekDateOf(day)
+		require.Equal(t, day, weekDate.Time(time.UTC))
+
+		parsed, err := iso8601.ParseWeekDate(weekDate.String())
+		require.NoError(t, err)
+		require.Equal(t, day, parsed)
+	}
+}
+
+func TestWeekDate_Time(t *testing.T) {
+	berlin := mustLoadLocation(t, "Europe/Berlin")
+
+	actual := iso8601.WeekDate{Year: 2024, Week: 11, Day: 5}.Time(berlin)
+	assert.Equal(t, time.Date(2024, 3, 15, 0, 0, 0, 0, berlin), actual)
+}
+
+func TestWeekDate_AddDuration(t *testing.T) {
+	testCases := []struct {
+		name     string
+		weekDate iso8601.WeekDate
+		dur      string
+		expected iso8601.WeekDate
+	}{
+		{
+			name:     "within the year",
+			weekDate: iso8601.WeekDate{Year: 2024, Week: 11, Day: 5},
+			dur:      "P2W",
+			expected: iso8601.WeekDate{Year: 2024, Week: 13, Day: 5},
+		},
+		{
+			name:     "into week 53",
+			weekDate: iso8601.WeekDate{Year: 2020, Week: 50, Day: 3},
+			dur:      "P3W",
+			expected: iso8601.WeekDate{Year: 2020, Week: 53, Day: 3},
+		},
+		{
+			name:     "over week 53",
+			weekDate: iso8601.WeekDate{Year: 2020, Week: 52, Day: 7},
+			dur:      "P2W",
+			expected: iso8601.WeekDate{Year: 2021, Week: 1, Day: 7},
+		},
+		{
+			name:     "over a 52 week year",
+			weekDate: iso8601.WeekDate{Year: 2024, Week: 52, Day: 1},
+			dur:      "P1W",
+			expected: iso8601.WeekDate{Year: 2025, Week: 1, Day: 1},
+		},
+		{
+			name:     "negative",
+			weekDate: iso8601.WeekDate{Year: 2021, Week: 1, Day: 1},
+			dur:      "-P1W",
+			expected: iso8601.WeekDate{Year: 2020, Week: 53, Day: 1},
+		},
+		{
+			name:     "zero",
+			weekDate: iso8601.WeekDate{Year: 2021, Week: 1, Day: 1},
+			dur:      "P0W",
+			expected: iso8601.WeekDate{Year: 2021, Week: 1, Day: 1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := tc.weekDate.AddDuration(mustDurationFromString(t, tc.dur))
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestWeekDate_AddDuration_Error(t *testing.T) {
+	weekDate := iso8601.WeekDate{Year: 2024, Week: 11, Day: 5}
+
+	for _, dur := range []string{"P1D", "P1W1D", "P1WT1H", "P1M", "P1.5W", "P9223372036854775807W"} {
+		t.Run(dur, func(t *testing.T) {
+			_, err := weekDate.AddDuration(mustDurationFromString(t, dur))
+			assert.Error(t, err)
+		})
+	}
+}